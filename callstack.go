@@ -3,32 +3,122 @@ package errors
 import (
 	"fmt"
 	"runtime"
+	"strings"
 )
 
 type callStack []uintptr
 
 // newCallStack creates a callStack of calls skipping the call to
-// runtime.Callers, newCallStack and the caller of newCallStack.
+// runtime.Callers, newCallStack, the caller of newCallStack and skip more
+// frames.
 // The newCallStack is skipped because it's meant to be used by the errors
 // consturctors and they shouldn't appear in the error value call stack.
-func newCallStack() callStack {
+// It returns nil if the call stacks are disabled by the package configuration
+// and it doesn't capture more frames than the maximum depth set by it.
+func newCallStack(skip int) callStack {
+	var cfg = getConfig()
+	if cfg.callStackDisabled {
+		return nil
+	}
+
+	if skip < 0 {
+		skip = 0
+	}
+
+	var depth = 20
+	if cfg.callStackMaxDepth > 0 && cfg.callStackMaxDepth < depth {
+		depth = cfg.callStackMaxDepth
+	}
+
 	var (
-		depth = 20
-		pcs   = make([]uintptr, depth)
-		l     = runtime.Callers(3, pcs)
+		pcs = make([]uintptr, depth)
+		l   = runtime.Callers(3+skip, pcs)
 	)
 
-	for l == depth {
+	for l == depth && (cfg.callStackMaxDepth == 0 || depth < cfg.callStackMaxDepth) {
 		depth += 10
+		if cfg.callStackMaxDepth > 0 && depth > cfg.callStackMaxDepth {
+			depth = cfg.callStackMaxDepth
+		}
+
 		pcs = make([]uintptr, depth)
-		l = runtime.Callers(3, pcs)
+		l = runtime.Callers(3+skip, pcs)
+	}
+
+	pcs = pcs[:l]
+
+	return callStack(pcs)
+}
+
+// newPanicCallStack creates a callStack of the calls of the panicking
+// goroutine, from the frame which panicked; it's meant to be used by the
+// functions which are deferred and recover from panics, hence the frames of
+// the deferred calls and of the runtime panic handling are skipped.
+// It returns nil if the call stacks are disabled by the package configuration
+// and it doesn't capture more frames than the maximum depth set by it.
+func newPanicCallStack() callStack {
+	var cfg = getConfig()
+	if cfg.callStackDisabled {
+		return nil
+	}
+
+	var (
+		pcs = make([]uintptr, 64)
+		l   = runtime.Callers(2, pcs)
+	)
+
+	for l == len(pcs) {
+		pcs = make([]uintptr, 2*len(pcs))
+		l = runtime.Callers(2, pcs)
 	}
 
 	pcs = pcs[:l]
 
+	// Skip the frames until runtime.gopanic and the runtime frames which
+	// follow it (e.g. runtime.panicmem and runtime.sigpanic, or the map
+	// implementation, for the panics raised by the runtime).
+	var start = -1
+	for i := range pcs {
+		var f, _ = runtime.CallersFrames(pcs[i : i+1]).Next()
+		if start < 0 {
+			if f.Function == "runtime.gopanic" {
+				start = i + 1
+			}
+
+			continue
+		}
+
+		if !strings.HasPrefix(f.Function, "runtime.") && !strings.HasPrefix(f.Function, "internal/runtime/") {
+			start = i
+			break
+		}
+	}
+
+	if start >= 0 && start < len(pcs) {
+		pcs = pcs[start:]
+	}
+
+	if cfg.callStackMaxDepth > 0 && len(pcs) > cfg.callStackMaxDepth {
+		pcs = pcs[:cfg.callStackMaxDepth]
+	}
+
 	return callStack(pcs)
 }
 
+// diff returns the frames of cs which aren't in the common tail of cs and o,
+// which are the frames that differ between both call stacks when cs is
+// captured later than o in the same goroutine. It always returns, at least,
+// the first frame of cs.
+func (cs callStack) diff(o callStack) callStack {
+	var i, j = len(cs) - 1, len(o) - 1
+	for i > 0 && j >= 0 && cs[i] == o[j] {
+		i--
+		j--
+	}
+
+	return cs[:i+1]
+}
+
 // Format satisfies the fmt.Formatter interface.
 // It only prints the value if the verb 'v' and flag '+' are used, printing the
 // complete function identifier (package path + name), the file and the line.
@@ -13,7 +13,7 @@ func TestCallStack_Format(t *testing.T) {
 	var (
 		ptName    = t.Name()
 		cstk      callStack
-		skippedFn = func() { cstk = newCallStack() }
+		skippedFn = func() { cstk = newCallStack(0) }
 		f1        = func() { skippedFn() }
 	)
 	f1()
@@ -52,3 +52,70 @@ func TestCallStack_Format(t *testing.T) {
 		assert.Empty(t, s)
 	})
 }
+
+func TestNewCallStack(t *testing.T) {
+	var recursive func(n int) callStack
+	recursive = func(n int) callStack {
+		if n == 0 {
+			return newCallStack(0)
+		}
+
+		return recursive(n - 1)
+	}
+
+	t.Run("without maximum depth", func(t *testing.T) {
+		var cstk = recursive(50)
+		assert.True(t, len(cstk) > 50)
+	})
+
+	t.Run("with maximum depth", func(t *testing.T) {
+		defer pkgConfig.Store(getConfig())
+
+		Configure(WithCallStackMaxDepth(5))
+		assert.Len(t, recursive(50), 5)
+
+		Configure(WithCallStackMaxDepth(25))
+		assert.Len(t, recursive(50), 25)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		defer pkgConfig.Store(getConfig())
+
+		Configure(WithCallStack(false))
+		assert.Nil(t, recursive(5))
+	})
+
+	t.Run("skip frames", func(t *testing.T) {
+		var (
+			inner = func(skip int) callStack { return newCallStack(skip) }
+			outer = func(skip int) callStack { return inner(skip) }
+			frs0  = strings.Split(fmt.Sprintf("%-v", outer(0)), "\n")
+			frs1  = strings.Split(fmt.Sprintf("%-v", outer(1)), "\n")
+		)
+
+		assert.Equal(t, frs0[1], frs1[0])
+		assert.Len(t, frs1, len(frs0)-1)
+	})
+}
+
+func TestCallStack_diff(t *testing.T) {
+	var tcases = []struct {
+		desc  string
+		cs    callStack
+		o     callStack
+		expcs callStack
+	}{
+		{desc: "common tail", cs: callStack{10, 11, 3, 4}, o: callStack{1, 2, 3, 4}, expcs: callStack{10, 11}},
+		{desc: "longer than the other", cs: callStack{10, 11, 2, 3, 4}, o: callStack{3, 4}, expcs: callStack{10, 11, 2}},
+		{desc: "nothing in common", cs: callStack{10, 11}, o: callStack{1, 2}, expcs: callStack{10, 11}},
+		{desc: "equal", cs: callStack{1, 2}, o: callStack{1, 2}, expcs: callStack{1}},
+		{desc: "other is empty", cs: callStack{1, 2}, o: nil, expcs: callStack{1, 2}},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expcs, tc.cs.diff(tc.o))
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"go.fraixed.es/errors"
+	"google.golang.org/grpc/codes"
+	"gopkg.in/yaml.v3"
+)
+
+// catalog is the list of Codes, and how to generate them, read from a catalog
+// file.
+type catalog struct {
+	// Package is the name of the Go package of the generated file.
+	Package string `yaml:"package" json:"package"`
+	// Type is the name of the generated Code type. It's "Code" by default.
+	Type string `yaml:"type" json:"type"`
+	// Namespace prefixes the string representation of the Codes, separated by
+	// a dot, e.g. "storage.NotFound".
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// Doc is the documentation of the Code type. It must start with the name
+	// of the type.
+	Doc string `yaml:"doc" json:"doc"`
+	// Codes are the Codes.
+	Codes []catalogCode `yaml:"codes" json:"codes"`
+}
+
+// catalogCode is a Code of a catalog.
+type catalogCode struct {
+	// Name is the name of the Code. It's used for the name of its constant,
+	// prefixed by the type name, and for its string representation.
+	Name string `yaml:"name" json:"name"`
+	// Message is the message of the Code.
+	Message string `yaml:"message" json:"message"`
+	// Template is the message template of the Code (see
+	// errors.MessageTemplater). It's optional.
+	Template string `yaml:"template" json:"template"`
+	// HTTPStatus is the HTTP status code of the Code. It's optional.
+	HTTPStatus int `yaml:"http_status" json:"http_status"`
+	// GRPCCode is the name of the gRPC code of the Code, e.g. "NotFound". It's
+	// optional.
+	GRPCCode string `yaml:"grpc_code" json:"grpc_code"`
+	// Severity is the severity of the errors identified by the Code: "debug",
+	// "info", "warn", "error" or "fatal". It's optional.
+	Severity string `yaml:"severity" json:"severity"`
+	// Category is the category of the errors identified by the Code:
+	// "client", "server", "dependency" or "security". It's optional.
+	Category string `yaml:"category" json:"category"`
+	// Doc is the documentation of the Code. It must start with the name of
+	// the Code or the name of its constant.
+	Doc string `yaml:"doc" json:"doc"`
+}
+
+// severities are the names of the severity levels of the catalog Codes
+// indexed by their valid values.
+var severities = map[string]string{
+	"debug": "SeverityDebug",
+	"info":  "SeverityInfo",
+	"warn":  "SeverityWarn",
+	"error": "SeverityError",
+	"fatal": "SeverityFatal",
+}
+
+// categories are the names of the categories of the catalog Codes indexed by
+// their valid values.
+var categories = map[string]string{
+	"client":     "CategoryClient",
+	"server":     "CategoryServer",
+	"dependency": "CategoryDependency",
+	"security":   "CategorySecurity",
+}
+
+// grpcCodes are the gRPC codes by their name, except codes.OK, which isn't an
+// error and it's returned by the generated GRPCCode methods for the Codes
+// without any.
+var grpcCodes = func() map[string]codes.Code {
+	var gcs = map[string]codes.Code{}
+	for c := codes.Canceled; c <= codes.Unauthenticated; c++ {
+		gcs[c.String()] = c
+	}
+
+	return gcs
+}()
+
+// parseCatalog parses the catalog data read from the file path, whose format
+// is determined by its extension: ".json", ".yaml" or ".yml". It returns an
+// error if data isn't a valid catalog.
+func parseCatalog(path string, data []byte) (*catalog, error) {
+	var cat catalog
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		var dec = json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cat); err != nil {
+			return nil, fmt.Errorf("invalid JSON catalog %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		var dec = yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&cat); err != nil {
+			return nil, fmt.Errorf("invalid YAML catalog %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported catalog format %q, it must be .json, .yaml or .yml", ext)
+	}
+
+	if cat.Type == "" {
+		cat.Type = "Code"
+	}
+
+	cat.Doc = strings.TrimSpace(cat.Doc)
+	for i := range cat.Codes {
+		cat.Codes[i].Doc = strings.TrimSpace(cat.Codes[i].Doc)
+	}
+
+	if err := cat.validate(); err != nil {
+		return nil, fmt.Errorf("invalid catalog %s: %w", path, err)
+	}
+
+	return &cat, nil
+}
+
+// validate returns an error if cat isn't valid.
+func (cat *catalog) validate() error {
+	if !token.IsIdentifier(cat.Package) {
+		return fmt.Errorf("package %q isn't a valid Go package name", cat.Package)
+	}
+
+	if !token.IsIdentifier(cat.Type) {
+		return fmt.Errorf("type %q isn't a valid Go identifier", cat.Type)
+	}
+
+	if cat.Doc != "" && !strings.HasPrefix(cat.Doc, cat.Type+" ") {
+		return fmt.Errorf("doc must start with %q", cat.Type)
+	}
+
+	if len(cat.Codes) == 0 {
+		return fmt.Errorf("there aren't codes")
+	}
+
+	var names = map[string]struct{}{}
+	for i, c := range cat.Codes {
+		if !token.IsIdentifier(c.Name) || !token.IsExported(c.Name) {
+			return fmt.Errorf("code %d: name %q isn't a valid exported Go identifier", i, c.Name)
+		}
+
+		if _, ok := names[c.Name]; ok {
+			return fmt.Errorf("code %s: duplicated name", c.Name)
+		}
+		names[c.Name] = struct{}{}
+
+		if c.Message == "" {
+			return fmt.Errorf("code %s: empty message", c.Name)
+		}
+
+		if c.Doc != "" && !strings.HasPrefix(c.Doc, c.Name+" ") && !strings.HasPrefix(c.Doc, cat.Type+c.Name+" ") {
+			return fmt.Errorf("code %s: doc must start with %q or %q", c.Name, c.Name, cat.Type+c.Name)
+		}
+
+		if err := errors.ValidateMessage(c.Template); err != nil {
+			return fmt.Errorf("code %s: invalid message template: %w", c.Name, err)
+		}
+
+		if c.HTTPStatus != 0 && (c.HTTPStatus < 100 || c.HTTPStatus > 599) {
+			return fmt.Errorf("code %s: invalid HTTP status %d", c.Name, c.HTTPStatus)
+		}
+
+		if _, ok := grpcCodes[c.GRPCCode]; c.GRPCCode != "" && !ok {
+			return fmt.Errorf("code %s: unknown gRPC code %q", c.Name, c.GRPCCode)
+		}
+
+		if _, ok := severities[c.Severity]; c.Severity != "" && !ok {
+			return fmt.Errorf("code %s: invalid severity %q", c.Name, c.Severity)
+		}
+
+		if _, ok := categories[c.Category]; c.Category != "" && !ok {
+			return fmt.Errorf("code %s: invalid category %q", c.Name, c.Category)
+		}
+	}
+
+	return nil
+}
+
+// CodeString returns the string representation of c in cat.
+func (cat *catalog) CodeString(c catalogCode) string {
+	if cat.Namespace == "" {
+		return c.Name
+	}
+
+	return cat.Namespace + "." + c.Name
+}
+
+// ConstDoc returns the documentation of the constant of c, which starts with
+// the name of the constant as the Go doc comments do.
+func (cat *catalog) ConstDoc(c catalogCode) string {
+	var name = cat.Type + c.Name
+	if c.Doc == "" {
+		return wrap(fmt.Sprintf("%s identifies the errors whose message is %q.", name, c.Message), docWidth)
+	}
+
+	if strings.HasPrefix(c.Doc, name+" ") {
+		return c.Doc
+	}
+
+	return name + strings.TrimPrefix(c.Doc, c.Name)
+}
+
+// HasTemplate returns true if any of the Codes has a message template.
+func (cat *catalog) HasTemplate() bool {
+	for _, c := range cat.Codes {
+		if c.Template != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasHTTPStatus returns true if any of the Codes has an HTTP status.
+func (cat *catalog) HasHTTPStatus() bool {
+	for _, c := range cat.Codes {
+		if c.HTTPStatus != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasGRPCCode returns true if any of the Codes has a gRPC code.
+func (cat *catalog) HasGRPCCode() bool {
+	for _, c := range cat.Codes {
+		if c.GRPCCode != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSeverity returns true if any of the Codes has a severity.
+func (cat *catalog) HasSeverity() bool {
+	for _, c := range cat.Codes {
+		if c.Severity != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasCategory returns true if any of the Codes has a category.
+func (cat *catalog) HasCategory() bool {
+	for _, c := range cat.Codes {
+		if c.Category != "" {
+			return true
+		}
+	}
+
+	return false
+}
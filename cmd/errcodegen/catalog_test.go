@@ -0,0 +1,141 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCatalog(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		var cat, err = parseCatalog("codes.yml", []byte(`
+package: storage
+codes:
+  - name: NotFound
+    message: not found
+    doc: "  NotFound is returned when the item doesn't exist.\n"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, &catalog{
+			Package: "storage",
+			Type:    "Code",
+			Codes: []catalogCode{
+				{Name: "NotFound", Message: "not found", Doc: "NotFound is returned when the item doesn't exist."},
+			},
+		}, cat)
+		assert.Equal(t, "NotFound", cat.CodeString(cat.Codes[0]))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var cat, err = parseCatalog("codes.json", []byte(`{
+			"package": "storage",
+			"namespace": "storage",
+			"codes": [{"name": "NotFound", "message": "not found", "http_status": 404, "grpc_code": "NotFound"}]
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, "storage.NotFound", cat.CodeString(cat.Codes[0]))
+		assert.True(t, cat.HasHTTPStatus())
+		assert.True(t, cat.HasGRPCCode())
+	})
+
+	var tcases = []struct {
+		desc string
+		path string
+		data string
+	}{
+		{
+			desc: "unsupported format",
+			path: "codes.toml",
+			data: `package = "storage"`,
+		},
+		{
+			desc: "unknown field",
+			path: "codes.yaml",
+			data: "package: storage\nother: 1\ncodes:\n  - {name: NotFound, message: not found}",
+		},
+		{
+			desc: "unknown JSON field",
+			path: "codes.json",
+			data: `{"package": "storage", "codes": [{"name": "NotFound", "message": "not found", "other": 1}]}`,
+		},
+		{
+			desc: "invalid package name",
+			path: "codes.yaml",
+			data: "package: my-storage\ncodes:\n  - {name: NotFound, message: not found}",
+		},
+		{
+			desc: "invalid type name",
+			path: "codes.yaml",
+			data: "package: storage\ntype: 1Code\ncodes:\n  - {name: NotFound, message: not found}",
+		},
+		{
+			desc: "no codes",
+			path: "codes.yaml",
+			data: "package: storage",
+		},
+		{
+			desc: "unexported code name",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: notFound, message: not found}",
+		},
+		{
+			desc: "duplicated code name",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found}\n  - {name: NotFound, message: other}",
+		},
+		{
+			desc: "empty message",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound}",
+		},
+		{
+			desc: "malformed message template",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, template: 'item {id not found'}",
+		},
+		{
+			desc: "invalid HTTP status",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, http_status: 4040}",
+		},
+		{
+			desc: "unknown gRPC code",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, grpc_code: Missing}",
+		},
+		{
+			desc: "OK gRPC code",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, grpc_code: OK}",
+		},
+		{
+			desc: "doc without the name of the type",
+			path: "codes.yaml",
+			data: "package: storage\ndoc: The storage codes.\ncodes:\n  - {name: NotFound, message: not found}",
+		},
+		{
+			desc: "doc without the name of the code",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, doc: The item doesn't exist.}",
+		},
+		{
+			desc: "invalid category",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, category: network}",
+		},
+		{
+			desc: "invalid severity",
+			path: "codes.yaml",
+			data: "package: storage\ncodes:\n  - {name: NotFound, message: not found, severity: critical}",
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var _, err = parseCatalog(tc.path, []byte(tc.data))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// goTmpl is the template of the generated Go file.
+var goTmpl = template.Must(template.New("go").Funcs(template.FuncMap{
+	"category":   func(s string) string { return categories[s] },
+	"comment":    comment,
+	"escape":     escapeBraces.Replace,
+	"severity":   func(s string) string { return severities[s] },
+	"statusText": http.StatusText,
+}).Parse(`// Code generated by errcodegen from {{.Source}}; DO NOT EDIT.
+
+package {{.Cat.Package}}
+
+import (
+	"go.fraixed.es/errors"
+{{- if .Cat.HasGRPCCode}}
+	"google.golang.org/grpc/codes"
+{{- end}}
+)
+
+{{if .Cat.Doc}}{{comment .Cat.Doc ""}}{{else}}// {{.Cat.Type}} is the type of the Codes of the errors of this package.{{end}}
+type {{.Cat.Type}} uint16
+
+const (
+{{- range $i, $c := .Cat.Codes}}
+{{comment ($.Cat.ConstDoc $c) "\t"}}
+	{{$.Cat.Type}}{{$c.Name}}{{if eq $i 0}} {{$.Cat.Type}} = iota + 1{{end}}
+{{- end}}
+)
+
+func init() {
+	errors.MustRegister(
+{{- range .Cat.Codes}}
+		{{$.Cat.Type}}{{.Name}},
+{{- end}}
+	)
+}
+
+// String satisfies the errors.Code interface.
+func (c {{.Cat.Type}}) String() string {
+	switch c {
+{{- range .Cat.Codes}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return {{printf "%q" ($.Cat.CodeString .)}}
+{{- end}}
+	default:
+		return {{printf "%q" .Unknown}}
+	}
+}
+
+// Message satisfies the errors.Code interface.
+func (c {{.Cat.Type}}) Message() string {
+	switch c {
+{{- range .Cat.Codes}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return {{printf "%q" .Message}}
+{{- end}}
+	default:
+		return "unknown error code"
+	}
+}
+{{- if .Cat.HasTemplate}}
+
+// MessageTemplate satisfies the errors.MessageTemplater interface.
+func (c {{.Cat.Type}}) MessageTemplate() string {
+	switch c {
+{{- range .Cat.Codes}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return {{if .Template}}{{printf "%q" .Template}}{{else}}{{printf "%q" (escape .Message)}}{{end}}
+{{- end}}
+	default:
+		return "unknown error code"
+	}
+}
+{{- end}}
+{{- if .Cat.HasHTTPStatus}}
+
+// HTTPStatus satisfies the errors.HTTPStatuser interface.
+func (c {{.Cat.Type}}) HTTPStatus() int {
+	switch c {
+{{- range .Cat.Codes}}{{if .HTTPStatus}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return {{.HTTPStatus}} // {{statusText .HTTPStatus}}
+{{- end}}{{end}}
+	default:
+		return 0 // Not declared
+	}
+}
+{{- end}}
+{{- if .Cat.HasGRPCCode}}
+
+// GRPCCode satisfies the go.fraixed.es/errors/grpcerr Coder interface.
+func (c {{.Cat.Type}}) GRPCCode() codes.Code {
+	switch c {
+{{- range .Cat.Codes}}{{if .GRPCCode}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return codes.{{.GRPCCode}}
+{{- end}}{{end}}
+	default:
+		return codes.OK // Not declared
+	}
+}
+{{- end}}
+{{- if .Cat.HasSeverity}}
+
+// Severity satisfies the errors.Severitier interface.
+func (c {{.Cat.Type}}) Severity() errors.SeverityLevel {
+	switch c {
+{{- range .Cat.Codes}}{{if .Severity}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return errors.{{severity .Severity}}
+{{- end}}{{end}}
+	default:
+		return 0 // Not declared
+	}
+}
+{{- end}}
+{{- if .Cat.HasCategory}}
+
+// Category satisfies the errors.Categorizer interface.
+func (c {{.Cat.Type}}) Category() errors.CategoryKind {
+	switch c {
+{{- range .Cat.Codes}}{{if .Category}}
+	case {{$.Cat.Type}}{{.Name}}:
+		return errors.{{category .Category}}
+{{- end}}{{end}}
+	default:
+		return 0 // Not declared
+	}
+}
+{{- end}}
+`))
+
+// mdTmpl is the template of the generated Markdown reference.
+var mdTmpl = template.Must(template.New("md").Funcs(template.FuncMap{
+	"anchor":     anchor,
+	"cell":       cell,
+	"statusText": http.StatusText,
+}).Parse(`# {{.Title}} error codes
+{{- if .Cat.Doc}}
+
+{{.Cat.Doc}}
+{{- end}}
+
+| Code | Message |
+| ---- | ------- |
+{{- range .Cat.Codes}}
+| [{{$.Cat.CodeString .}}](#{{anchor ($.Cat.CodeString .)}}) | {{cell .Message}} |
+{{- end}}
+{{- range .Cat.Codes}}
+
+## {{$.Cat.CodeString .}}
+
+{{.Message}}
+{{- if or .Template .HTTPStatus .GRPCCode .Severity .Category}}
+{{if .Template}}
+- Message template: {{.Template}}
+{{- end}}
+{{- if .HTTPStatus}}
+- HTTP status: {{.HTTPStatus}} {{statusText .HTTPStatus}}
+{{- end}}
+{{- if .GRPCCode}}
+- gRPC code: {{.GRPCCode}}
+{{- end}}
+{{- if .Severity}}
+- Severity: {{.Severity}}
+{{- end}}
+{{- if .Category}}
+- Category: {{.Category}}
+{{- end}}
+{{- end}}
+{{- if .Doc}}
+
+{{.Doc}}
+{{- end}}
+{{- end}}
+`))
+
+// generateGo returns the Go source code of the Codes of cat, which has been
+// read from the file src.
+func generateGo(cat *catalog, src string) ([]byte, error) {
+	var unknown = "UnknownCode"
+	if cat.Namespace != "" {
+		unknown = cat.Namespace + "." + unknown
+	}
+
+	var buf bytes.Buffer
+	if err := goTmpl.Execute(&buf, struct {
+		Cat     *catalog
+		Source  string
+		Unknown string
+	}{Cat: cat, Source: src, Unknown: unknown}); err != nil {
+		return nil, err
+	}
+
+	var code, err = format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated Go code is invalid: %w", err)
+	}
+
+	return code, nil
+}
+
+// generateMarkdown returns the Markdown reference of the Codes of cat.
+func generateMarkdown(cat *catalog) ([]byte, error) {
+	var title = cat.Namespace
+	if title == "" {
+		title = cat.Package
+	}
+
+	var buf bytes.Buffer
+	if err := mdTmpl.Execute(&buf, struct {
+		Cat   *catalog
+		Title string
+	}{Cat: cat, Title: title}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// comment returns text as a Go comment whose lines are prefixed by indent.
+func comment(text, indent string) string {
+	var lines = strings.Split(strings.TrimSpace(text), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(indent+"// "+l, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// docWidth is the maximum width of the generated documentation lines, without
+// their indentation and comment markers.
+const docWidth = 72
+
+// wrap returns text with its words wrapped in lines of width characters at
+// most, except for the words which are longer.
+func wrap(text string, width int) string {
+	var (
+		sb  strings.Builder
+		col int
+	)
+
+	for _, w := range strings.Fields(text) {
+		switch {
+		case col == 0:
+		case col+1+len(w) > width:
+			sb.WriteByte('\n')
+			col = 0
+		default:
+			sb.WriteByte(' ')
+			col++
+		}
+
+		sb.WriteString(w)
+		col += len(w)
+	}
+
+	return sb.String()
+}
+
+// escapeBraces escapes the braces of a message for using it as a message
+// template.
+var escapeBraces = strings.NewReplacer("{", "{{", "}", "}}")
+
+// anchor returns the anchor of the Markdown heading s, following the GitHub
+// rules.
+func anchor(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+
+	return sb.String()
+}
+
+// cell returns s escaped for being the content of a Markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
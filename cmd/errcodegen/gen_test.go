@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the generated files of the example package")
+
+func TestGenerate(t *testing.T) {
+	var (
+		dir = filepath.Join("internal", "storage")
+		in  = filepath.Join(dir, "codes.yaml")
+	)
+
+	var data, err = os.ReadFile(in)
+	require.NoError(t, err)
+
+	cat, err := parseCatalog(in, data)
+	require.NoError(t, err)
+
+	code, err := generateGo(cat, "codes.yaml")
+	require.NoError(t, err)
+
+	md, err := generateMarkdown(cat)
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "codes_gen.go"), code, 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "codes.md"), md, 0o644))
+	}
+
+	expCode, err := os.ReadFile(filepath.Join(dir, "codes_gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expCode), string(code))
+
+	expMD, err := os.ReadFile(filepath.Join(dir, "codes.md"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expMD), string(md))
+}
+
+func TestGenerateGo_withoutMappings(t *testing.T) {
+	var cat, err = parseCatalog("codes.json", []byte(`{
+		"package": "users",
+		"type": "ErrCode",
+		"codes": [{"name": "Invalid", "message": "the user is invalid"}]
+	}`))
+	require.NoError(t, err)
+
+	code, err := generateGo(cat, "codes.json")
+	require.NoError(t, err)
+
+	var src = string(code)
+	assert.Contains(t, src, "package users\n")
+	assert.Contains(t, src, "// ErrCode is the type of the Codes of the errors of this package.\ntype ErrCode uint16")
+	assert.Contains(t, src, "ErrCodeInvalid ErrCode = iota + 1")
+	assert.Contains(t, src, `return "Invalid"`)
+	assert.Contains(t, src, `return "UnknownCode"`)
+	assert.NotContains(t, src, "HTTPStatus")
+	assert.NotContains(t, src, "GRPCCode")
+	assert.NotContains(t, src, "google.golang.org/grpc/codes")
+}
+
+func TestComment(t *testing.T) {
+	assert.Equal(t, "\t// First line.\n\t//\n\t// Second line.", comment("First line.\n\nSecond line.\n", "\t"))
+}
+
+func TestCatalog_ConstDoc(t *testing.T) {
+	var cat = catalog{Type: "Code"}
+
+	assert.Equal(t,
+		"CodeNotFound is returned when the item doesn't exist.",
+		cat.ConstDoc(catalogCode{Name: "NotFound", Doc: "NotFound is returned when the item doesn't exist."}),
+	)
+	assert.Equal(t,
+		"CodeNotFound is returned when the item doesn't exist.",
+		cat.ConstDoc(catalogCode{Name: "NotFound", Doc: "CodeNotFound is returned when the item doesn't exist."}),
+	)
+	assert.Equal(t,
+		"CodeConflict identifies the errors whose message is \"the item has been\nmodified by another request\".",
+		cat.ConstDoc(catalogCode{Name: "Conflict", Message: "the item has been modified by another request"}),
+	)
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, "a bb\nccc\ndddddd\ne", wrap("a bb ccc  dddddd e", 4))
+	assert.Equal(t, "", wrap("", 4))
+}
+
+func TestAnchor(t *testing.T) {
+	assert.Equal(t, "storagenotfound", anchor("storage.NotFound"))
+	assert.Equal(t, "a-b_c", anchor("A B_c!"))
+}
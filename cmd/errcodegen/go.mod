@@ -0,0 +1,16 @@
+module go.fraixed.es/errors/cmd/errcodegen
+
+go 1.25.0
+
+require (
+	github.com/stretchr/testify v1.2.2
+	go.fraixed.es/errors v0.0.0-20261018105732-49fb1fae7420
+	google.golang.org/grpc v1.84.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
@@ -0,0 +1,123 @@
+// Code generated by errcodegen from codes.yaml; DO NOT EDIT.
+
+package storage
+
+import (
+	"go.fraixed.es/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// Code is the type of the Codes of the errors returned by the storage.
+type Code uint16
+
+const (
+	// CodeNotFound is returned when the requested item doesn't exist.
+	CodeNotFound Code = iota + 1
+	// CodeConflict identifies the errors whose message is "the item has been
+	// modified by another request".
+	CodeConflict
+	// CodeUnavailable is returned when the connection with the database fails.
+	//
+	// The operation can be retried later.
+	CodeUnavailable
+)
+
+func init() {
+	errors.MustRegister(
+		CodeNotFound,
+		CodeConflict,
+		CodeUnavailable,
+	)
+}
+
+// String satisfies the errors.Code interface.
+func (c Code) String() string {
+	switch c {
+	case CodeNotFound:
+		return "storage.NotFound"
+	case CodeConflict:
+		return "storage.Conflict"
+	case CodeUnavailable:
+		return "storage.Unavailable"
+	default:
+		return "storage.UnknownCode"
+	}
+}
+
+// Message satisfies the errors.Code interface.
+func (c Code) Message() string {
+	switch c {
+	case CodeNotFound:
+		return "the item doesn't exist"
+	case CodeConflict:
+		return "the item has been modified by another request"
+	case CodeUnavailable:
+		return "the storage | database isn't available"
+	default:
+		return "unknown error code"
+	}
+}
+
+// MessageTemplate satisfies the errors.MessageTemplater interface.
+func (c Code) MessageTemplate() string {
+	switch c {
+	case CodeNotFound:
+		return "the item {id} doesn't exist"
+	case CodeConflict:
+		return "the item {id} has been modified by another request"
+	case CodeUnavailable:
+		return "the storage | database isn't available"
+	default:
+		return "unknown error code"
+	}
+}
+
+// HTTPStatus satisfies the errors.HTTPStatuser interface.
+func (c Code) HTTPStatus() int {
+	switch c {
+	case CodeNotFound:
+		return 404 // Not Found
+	case CodeConflict:
+		return 409 // Conflict
+	default:
+		return 0 // Not declared
+	}
+}
+
+// GRPCCode satisfies the go.fraixed.es/errors/grpcerr Coder interface.
+func (c Code) GRPCCode() codes.Code {
+	switch c {
+	case CodeNotFound:
+		return codes.NotFound
+	case CodeConflict:
+		return codes.Aborted
+	default:
+		return codes.OK // Not declared
+	}
+}
+
+// Severity satisfies the errors.Severitier interface.
+func (c Code) Severity() errors.SeverityLevel {
+	switch c {
+	case CodeNotFound:
+		return errors.SeverityWarn
+	case CodeConflict:
+		return errors.SeverityInfo
+	case CodeUnavailable:
+		return errors.SeverityError
+	default:
+		return 0 // Not declared
+	}
+}
+
+// Category satisfies the errors.Categorizer interface.
+func (c Code) Category() errors.CategoryKind {
+	switch c {
+	case CodeNotFound:
+		return errors.CategoryClient
+	case CodeUnavailable:
+		return errors.CategoryDependency
+	default:
+		return 0 // Not declared
+	}
+}
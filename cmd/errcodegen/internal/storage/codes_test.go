@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.fraixed.es/errors"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCode(t *testing.T) {
+	var c, ok = errors.Lookup("storage.NotFound")
+	assert.True(t, ok)
+	assert.Equal(t, CodeNotFound, c)
+
+	var err = errors.New(CodeNotFound, errors.MD{K: "id", V: 5})
+	assert.Equal(t, "the item 5 doesn't exist", errors.Message(err))
+	assert.Equal(t, "the item doesn't exist", errors.PublicMessage(err))
+	assert.Equal(t, "the item 5 doesn't exist", errors.PublicMessage(errors.New(CodeNotFound, errors.PublicMD("id", 5))))
+	assert.Equal(t, "the storage | database isn't available", errors.TemplateOf(CodeUnavailable))
+	assert.Equal(t, http.StatusNotFound, errors.HTTPStatus(err))
+	assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatus(errors.New(CodeUnavailable)))
+	assert.Equal(t, http.StatusConflict, errors.HTTPStatus(errors.Wrap(errors.New(CodeConflict), CodeUnavailable)))
+	assert.Equal(t, codes.Aborted, CodeConflict.GRPCCode())
+	assert.Equal(t, codes.OK, CodeUnavailable.GRPCCode())
+	assert.Equal(t, "storage.UnknownCode", Code(0).String())
+
+	var sl, _ = errors.Severity(err)
+	assert.Equal(t, errors.SeverityWarn, sl)
+	var ck, _ = errors.Category(err)
+	assert.Equal(t, errors.CategoryClient, ck)
+	_, ok = errors.Category(errors.New(CodeConflict))
+	assert.False(t, ok)
+
+	ck, _ = errors.Category(errors.Wrap(errors.New(CodeNotFound), CodeConflict))
+	assert.Equal(t, errors.CategoryClient, ck)
+}
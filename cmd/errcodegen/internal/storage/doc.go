@@ -0,0 +1,5 @@
+// Package storage is an example of the Codes generated by errcodegen, which is
+// also used for testing it.
+package storage
+
+//go:generate go run go.fraixed.es/errors/cmd/errcodegen -in codes.yaml -out codes_gen.go -doc codes.md
@@ -0,0 +1,126 @@
+// Command errcodegen generates the Go types which implement the
+// go.fraixed.es/errors Code interface from a catalog file.
+//
+// Usage:
+//
+//	errcodegen -in codes.yaml -out codes_gen.go [-doc codes.md]
+//
+// It's meant to be used with go generate:
+//
+//	//go:generate go run go.fraixed.es/errors/cmd/errcodegen -in codes.yaml -out codes_gen.go -doc codes.md
+//
+// The catalog is a YAML (.yaml or .yml) or JSON (.json) file with the following
+// fields:
+//
+//	package: the name of the Go package of the generated file. Required.
+//	type: the name of the generated Code type. It's "Code" by default.
+//	namespace: the prefix of the string representation of the Codes, which
+//	           is separated by a dot, e.g. "storage.NotFound". Optional.
+//	doc: the documentation of the Code type, which must start with its name.
+//	     Optional.
+//	codes: the list of Codes, with the following fields:
+//	  name: the name of the Code. Its constant is named with the type name
+//	        followed by it and it's used for its string representation.
+//	        Required.
+//	  message: the message of the Code. Required.
+//	  template: the message template of the Code (see
+//	            errors.MessageTemplater); the Codes without it use their
+//	            message with the braces escaped. Optional.
+//	  http_status: the HTTP status code of the Code (see errors.HTTPStatuser).
+//	               Optional.
+//	  grpc_code: the name of the gRPC code of the Code, e.g. "NotFound", other
+//	             than "OK" (see the go.fraixed.es/errors/grpcerr Coder
+//	             interface). Optional.
+//	  severity: the severity of the Code: debug, info, warn, error or fatal
+//	            (see errors.Severitier). Optional.
+//	  category: the category of the Code: client, server, dependency or
+//	            security (see errors.Categorizer). Optional.
+//	  doc: the documentation of the Code, which must start with its name or the
+//	       name of its constant. Optional.
+//
+// For example:
+//
+//	package: storage
+//	namespace: storage
+//	codes:
+//	  - name: NotFound
+//	    message: the item doesn't exist
+//	    template: "the item {id} doesn't exist"
+//	    http_status: 404
+//	    grpc_code: NotFound
+//	    severity: warn
+//	    category: client
+//	    doc: NotFound is returned when the requested item doesn't exist.
+//
+// The generated file contains the type, a constant for each Code, the
+// registration of all of them in the default registry of the
+// go.fraixed.es/errors package, the String and Message methods and the
+// MessageTemplate, HTTPStatus, GRPCCode, Severity and Category methods when
+// any Code has them. The MessageTemplate method of the Codes without template
+// returns their message with the braces escaped, while their HTTPStatus,
+// GRPCCode, Severity and Category methods return 0 (codes.OK for GRPCCode),
+// so they are ignored and the ones of the wrapped errors are used (see
+// errors.HTTPStatuser, the go.fraixed.es/errors/grpcerr Coder interface,
+// errors.Severitier and errors.Categorizer). The Markdown reference,
+// generated when the -doc flag is set, lists all the Codes with their mappings
+// and documentation.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var (
+		in  = flag.String("in", "", "path of the catalog file (.yaml, .yml or .json)")
+		out = flag.String("out", "", "path of the generated Go file")
+		doc = flag.String("doc", "", "path of the generated Markdown reference (optional)")
+	)
+	flag.Parse()
+
+	if err := run(*in, *out, *doc); err != nil {
+		fmt.Fprintf(os.Stderr, "errcodegen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the Go file out, and the Markdown reference doc if it isn't
+// empty, from the catalog file in.
+func run(in, out, doc string) error {
+	if in == "" || out == "" {
+		return fmt.Errorf("the -in and -out flags are required")
+	}
+
+	var data, err = os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+
+	cat, err := parseCatalog(in, data)
+	if err != nil {
+		return err
+	}
+
+	code, err := generateGo(cat, filepath.Base(in))
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(out, code, 0o644); err != nil {
+		return err
+	}
+
+	if doc == "" {
+		return nil
+	}
+
+	md, err := generateMarkdown(cat)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(doc, md, 0o644)
+}
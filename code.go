@@ -5,3 +5,79 @@ type Code interface {
 	String() string
 	Message() string
 }
+
+// equalCodes returns true if a and b have the same string representation
+// (value returned by the String method) and the same message (value returned
+// by the Message method), otherwise false.
+func equalCodes(a, b Code) bool {
+	return a.String() == b.String() && a.Message() == b.Message()
+}
+
+// ErrCode is the type of the Codes of the errors returned by this package.
+type ErrCode uint8
+
+const (
+	// ErrCodeDuplicated identifies the errors returned when a Code cannot be
+	// registered because there is already a registered Code with the same
+	// string representation.
+	ErrCodeDuplicated ErrCode = iota + 1
+	// ErrCodeMalformedJSON identifies the errors returned when a JSON document
+	// isn't a valid representation of an error.
+	ErrCodeMalformedJSON
+	// ErrCodeMalformedTemplate identifies the errors returned when a message
+	// template isn't well formed (see ValidateMessage).
+	ErrCodeMalformedTemplate
+	// ErrCodeMissingTemplateKey identifies the errors returned when a message
+	// template has placeholders whose keys aren't in the metadata (see
+	// ExpandMessage).
+	ErrCodeMissingTemplateKey
+)
+
+// String satisfies the Code interface.
+func (c ErrCode) String() string {
+	switch c {
+	case ErrCodeDuplicated:
+		return "errors.DuplicatedCode"
+	case ErrCodeMalformedJSON:
+		return "errors.MalformedJSON"
+	case ErrCodeMalformedTemplate:
+		return "errors.MalformedTemplate"
+	case ErrCodeMissingTemplateKey:
+		return "errors.MissingTemplateKey"
+	default:
+		return "errors.UnknownCode"
+	}
+}
+
+// Message satisfies the Code interface.
+func (c ErrCode) Message() string {
+	switch c {
+	case ErrCodeDuplicated:
+		return "a code with the same string representation is already registered"
+	case ErrCodeMalformedJSON:
+		return "the JSON document isn't a valid representation of an error"
+	case ErrCodeMalformedTemplate:
+		return "the message template is malformed"
+	case ErrCodeMissingTemplateKey:
+		return "the metadata doesn't have some keys of the message template"
+	default:
+		return "unknown error code of the errors package"
+	}
+}
+
+// remoteCode is the Code used to reconstruct the errors, which are transmitted
+// over the wire, when their Codes aren't registered.
+type remoteCode struct {
+	s   string
+	msg string
+}
+
+// String satisfies the Code interface.
+func (c remoteCode) String() string {
+	return c.s
+}
+
+// Message satisfies the Code interface.
+func (c remoteCode) Message() string {
+	return c.msg
+}
@@ -0,0 +1,96 @@
+package errors
+
+import "sync/atomic"
+
+// Option is a function which modifies the package configuration. See
+// Configure.
+type Option func(*config)
+
+// config is the package configuration.
+type config struct {
+	// callStackDisabled indicates if the call stacks aren't captured.
+	callStackDisabled bool
+	// callStackMaxDepth is the maximum number of frames of the call stacks; 0
+	// means no limit.
+	callStackMaxDepth int
+	// idGenerator generates the IDs of the errors.
+	idGenerator IDGenerator
+	// wrappedCallStacks indicates if the call stacks of the wrapped errors are
+	// kept.
+	wrappedCallStacks bool
+}
+
+// pkgConfig holds the current package configuration.
+var pkgConfig atomic.Value
+
+func init() {
+	pkgConfig.Store(config{idGenerator: NewUUIDv7Generator()})
+}
+
+// Configure modifies the package configuration applying opts, in order, over
+// the current configuration.
+// It's meant to be called during the program initialization, although it's
+// safe for concurrent use; the errors created before calling it aren't
+// affected.
+func Configure(opts ...Option) {
+	var cfg = getConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	pkgConfig.Store(cfg)
+}
+
+// WithCallStack enables or disables capturing the call stack when the errors
+// are created. It's enabled by default.
+// Disabling it is useful for hot paths where errors are created very often
+// and their call stacks aren't needed.
+func WithCallStack(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.callStackDisabled = !enabled
+	}
+}
+
+// WithCallStackMaxDepth sets the maximum number of frames of the call stacks
+// captured when the errors are created. n equal or less than 0 means no limit,
+// which is the default.
+func WithCallStackMaxDepth(n int) Option {
+	return func(cfg *config) {
+		if n < 0 {
+			n = 0
+		}
+
+		cfg.callStackMaxDepth = n
+	}
+}
+
+// WithWrappedCallStacks enables or disables keeping the call stacks of the
+// errors, created by one of the constructors of this package, which are wrapped
+// by Wrap and WrapSkip. It's disabled by default, so their call stacks are
+// discarded.
+// When it's enabled, the call stack of the wrapped error is printed as the
+// place where the error started and the call stack of the error which wraps it
+// is printed as the place where it was wrapped, only containing the frames
+// which differ from the call stack of the wrapped error (see derror.Format).
+func WithWrappedCallStacks(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.wrappedCallStacks = enabled
+	}
+}
+
+// WithIDGenerator sets g as the generator of the IDs of the errors. By default,
+// the IDs are time-ordered UUIDs (see NewUUIDv7Generator). A nil g restores the
+// default.
+func WithIDGenerator(g IDGenerator) Option {
+	return func(cfg *config) {
+		if g == nil {
+			g = NewUUIDv7Generator()
+		}
+
+		cfg.idGenerator = g
+	}
+}
+
+func getConfig() config {
+	return pkgConfig.Load().(config)
+}
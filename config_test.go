@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigure(t *testing.T) {
+	defer pkgConfig.Store(getConfig())
+
+	Configure(WithCallStack(false), WithCallStackMaxDepth(5), WithIDGenerator(NewCounterGenerator(1)))
+	var cfg = getConfig()
+	assert.True(t, cfg.callStackDisabled)
+	assert.Equal(t, 5, cfg.callStackMaxDepth)
+	assert.Equal(t, "00000000000000000001", New(testCode(true)).(derror).id.String())
+
+	Configure(WithCallStack(true))
+	cfg = getConfig()
+	assert.False(t, cfg.callStackDisabled)
+	assert.Equal(t, 5, cfg.callStackMaxDepth)
+	assert.Equal(t, "00000000000000000002", New(testCode(true)).(derror).id.String())
+
+	Configure(WithCallStackMaxDepth(-1), WithIDGenerator(nil))
+	cfg = getConfig()
+	assert.Zero(t, cfg.callStackMaxDepth)
+	assert.IsType(t, &uuidv7Generator{}, cfg.idGenerator)
+}
@@ -1,38 +1,73 @@
 package errors
 
-import "github.com/gofrs/uuid"
-
 // New creates a new error with c and mds.
 func New(c Code, mds ...MD) error {
-	var id, _ = uuid.NewV4()
+	return newDerror(c, mds, newCallStack(0))
+}
+
+// NewSkip is like New but it skips skip frames of the call stack, so helper
+// functions which create errors for their callers don't appear as the origin
+// of the error. skip equal to 0 is the same than New.
+func NewSkip(skip int, c Code, mds ...MD) error {
+	return newDerror(c, mds, newCallStack(skip))
+}
+
+// Wrap creates a new error with c and mds, wrapping err.
+func Wrap(err error, c Code, mds ...MD) error {
+	return wrap(err, newDerror(c, mds, newCallStack(0)))
+}
+
+// WrapSkip is like Wrap but it skips skip frames of the call stack, so helper
+// functions which wrap errors for their callers don't appear as the origin of
+// the error. skip equal to 0 is the same than Wrap.
+func WrapSkip(skip int, err error, c Code, mds ...MD) error {
+	return wrap(err, newDerror(c, mds, newCallStack(skip)))
+}
+
+// Restore creates an error with c, id and mds, wrapping werr, which can be nil.
+// It's meant for reconstructing the errors which were created by another
+// process and transmitted over the wire, hence the returned error doesn't have
+// call stack.
+func Restore(c Code, id ID, werr error, mds ...MD) error {
+	return derror{
+		c:    c,
+		id:   id,
+		mds:  mds,
+		werr: werr,
+	}
+}
 
+func newDerror(c Code, mds []MD, cs callStack) derror {
 	return derror{
 		c:   c,
-		id:  id,
+		id:  getConfig().idGenerator.NewID(),
 		mds: mds,
-		cs:  newCallStack(),
+		cs:  cs,
 	}
 }
 
-// Wrap creates a new error with c and mds, wrapping err.
-func Wrap(err error, c Code, mds ...MD) error {
-	var (
-		id, _ = uuid.NewV4()
-		derr  = derror{
-			c:   c,
-			id:  id,
-			mds: mds,
-			cs:  newCallStack(),
-		}
-	)
-
-	if de, ok := err.(derror); ok {
-		de.cs = nil
+// wrap sets err as the error wrapped by derr. When err is a derror, its call
+// stack is discarded unless the package configuration keeps them, in such case
+// the roles of both call stacks are set.
+func wrap(err error, derr derror) derror {
+	var de, ok = err.(derror)
+	if !ok {
+		derr.werr = err
+		return derr
+	}
 
+	if !getConfig().wrappedCallStacks || len(de.cs) == 0 || len(derr.cs) == 0 {
+		de.cs = nil
 		derr.werr = de
-	} else {
-		derr.werr = err
+		return derr
 	}
 
+	if de.csr == csRoleNone {
+		de.csr = csRoleStarted
+	}
+
+	derr.csr = csRoleWrapped
+	derr.werr = de
+
 	return derr
 }
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +17,7 @@ func TestNew(t *testing.T) {
 
 		var derr = err.(derror)
 		assert.Equal(t, derr.c, testCode(true))
-		assert.NotEqual(t, uuid.UUID{}, derr.id)
+		assert.NotEmpty(t, derr.id)
 		assert.Empty(t, derr.mds)
 		assert.Nil(t, derr.werr)
 		assert.NotEmpty(t, derr.cs)
@@ -31,7 +30,7 @@ func TestNew(t *testing.T) {
 
 		var derr = err.(derror)
 		assert.Equal(t, derr.c, testCode(true))
-		assert.NotEqual(t, uuid.UUID{}, derr.id)
+		assert.NotEmpty(t, derr.id)
 		assert.Equal(t, derr.mds, mDatas{{K: "a", V: "va"}, {K: "b", V: "vb"}})
 		assert.Nil(t, derr.werr)
 		assert.NotEmpty(t, derr.cs)
@@ -49,7 +48,7 @@ func TestWrap(t *testing.T) {
 
 		var derr = err.(derror)
 		assert.Equal(t, derr.c, testCode(true))
-		assert.NotEqual(t, uuid.UUID{}, derr.id)
+		assert.NotEmpty(t, derr.id)
 		assert.Empty(t, derr.mds)
 		assert.Equal(t, extErr, derr.werr)
 		assert.NotEmpty(t, derr.cs)
@@ -65,7 +64,7 @@ func TestWrap(t *testing.T) {
 
 		var derr = err.(derror)
 		assert.Equal(t, derr.c, testCode(true))
-		assert.NotEqual(t, uuid.UUID{}, derr.id)
+		assert.NotEmpty(t, derr.id)
 		assert.Equal(t, derr.mds, mDatas{{K: "a", V: "va"}, {K: "b", V: "vb"}})
 		assert.Equal(t, extErr, derr.werr)
 		assert.NotEmpty(t, derr.cs)
@@ -81,7 +80,7 @@ func TestWrap(t *testing.T) {
 
 		var derr = err.(derror)
 		assert.Equal(t, derr.c, testCode(true))
-		assert.NotEqual(t, uuid.UUID{}, derr.id)
+		assert.NotEmpty(t, derr.id)
 		assert.Empty(t, derr.mds)
 		assert.NotEmpty(t, derr.cs)
 
@@ -113,7 +112,7 @@ func TestWrap(t *testing.T) {
 
 		var derr = err.(derror)
 		assert.Equal(t, derr.c, testCode(true))
-		assert.NotEqual(t, uuid.UUID{}, derr.id)
+		assert.NotEmpty(t, derr.id)
 		assert.Equal(t, derr.mds, mDatas{{K: "a", V: "va"}, {K: "b", V: "vb"}})
 		assert.NotEmpty(t, derr.cs)
 
@@ -135,3 +134,118 @@ func TestWrap(t *testing.T) {
 		assert.Len(t, parts, 2)
 	})
 }
+
+func TestRestore(t *testing.T) {
+	var (
+		id     = ID("a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b")
+		extErr = errors.New("ext error: " + t.Name())
+		err    = Restore(testCode(true), id, extErr, MD{K: "a", V: "va"})
+	)
+	require.IsType(t, derror{}, err)
+
+	var derr = err.(derror)
+	assert.Equal(t, derr.c, testCode(true))
+	assert.Equal(t, id, derr.id)
+	assert.Equal(t, derr.mds, mDatas{{K: "a", V: "va"}})
+	assert.Equal(t, extErr, derr.werr)
+	assert.Empty(t, derr.cs)
+}
+
+func TestNewSkip(t *testing.T) {
+	var newErr = func() error {
+		return NewSkip(1, testCode(true), MD{K: "a", V: "va"})
+	}
+
+	var err = newErr()
+	require.IsType(t, derror{}, err)
+
+	var derr = err.(derror)
+	assert.Equal(t, derr.c, testCode(true))
+	assert.Equal(t, derr.mds, mDatas{{K: "a", V: "va"}})
+	require.NotEmpty(t, derr.cs)
+
+	var frs = strings.Split(fmt.Sprintf("%-v", derr.cs), "\n")
+	assert.Equal(t, "\tgo.fraixed.es/errors.TestNewSkip", frs[0])
+}
+
+func TestWrapSkip(t *testing.T) {
+	var (
+		extErr  = errors.New("ext error: " + t.Name())
+		wrapErr = func() error {
+			return WrapSkip(1, extErr, testCode(true))
+		}
+	)
+
+	var err = wrapErr()
+	require.IsType(t, derror{}, err)
+
+	var derr = err.(derror)
+	assert.Equal(t, extErr, derr.werr)
+	require.NotEmpty(t, derr.cs)
+
+	var frs = strings.Split(fmt.Sprintf("%-v", derr.cs), "\n")
+	assert.Equal(t, "\tgo.fraixed.es/errors.TestWrapSkip", frs[0])
+}
+
+func TestNew_callStackDisabled(t *testing.T) {
+	defer pkgConfig.Store(getConfig())
+	Configure(WithCallStack(false))
+
+	var derr = New(testCode(true)).(derror)
+	assert.Empty(t, derr.cs)
+	assert.NotContains(t, fmt.Sprintf("%+v", derr), "call stack")
+}
+
+func TestWrap_wrappedCallStacks(t *testing.T) {
+	defer pkgConfig.Store(getConfig())
+	Configure(WithWrappedCallStacks(true))
+
+	var (
+		started = func() error { return New(differentTestCode(true)) }
+		wrapped = func() error { return Wrap(started(), testCode(true)) }
+		err     = Wrap(wrapped(), otherTestCode(true))
+	)
+
+	var (
+		derr  = err.(derror)
+		mderr = derr.werr.(derror)
+		ideer = mderr.werr.(derror)
+	)
+
+	assert.Equal(t, csRoleWrapped, derr.csr)
+	assert.Equal(t, csRoleWrapped, mderr.csr)
+	assert.Equal(t, csRoleStarted, ideer.csr)
+	assert.NotEmpty(t, derr.cs)
+	assert.NotEmpty(t, mderr.cs)
+	assert.NotEmpty(t, ideer.cs)
+
+	// The call stacks are complete, so they can be inspected.
+	assert.Equal(t, "go.fraixed.es/errors.TestWrap_wrappedCallStacks.func1", StackTraceAt(err, 2)[0].Function)
+	assert.Equal(t, "go.fraixed.es/errors.TestWrap_wrappedCallStacks.func2", StackTraceAt(err, 1)[0].Function)
+
+	var (
+		prcs  = fmt.Sprintf("%+v", err)
+		parts = strings.Split(prcs, "call stack (error wrapped here):")
+	)
+	require.Len(t, parts, 3)
+	assert.Contains(t, parts[0], "call stack (error started here):")
+
+	// Each wrapped here call stack only has the frames which differ.
+	assert.Equal(t, fmt.Sprintf("\n%v\n\t", mderr.cs.diff(ideer.cs)), parts[1])
+	assert.Equal(t, fmt.Sprintf("\n%v", derr.cs.diff(mderr.cs)), parts[2])
+	assert.Contains(t, parts[1], "TestWrap_wrappedCallStacks.func2")
+	assert.NotContains(t, parts[1], "TestWrap_wrappedCallStacks.func1")
+	assert.Contains(t, parts[2], "errors.TestWrap_wrappedCallStacks\n")
+	assert.NotContains(t, parts[2], "testing.tRunner")
+
+	var cprcs = fmt.Sprintf("%+-v", err)
+	assert.Contains(t, cprcs, "call stack (error wrapped here) (compacted):")
+
+	t.Run("disabled", func(t *testing.T) {
+		Configure(WithWrappedCallStacks(false))
+
+		var derr = Wrap(started(), testCode(true)).(derror)
+		assert.Equal(t, csRoleNone, derr.csr)
+		assert.Empty(t, derr.werr.(derror).cs)
+	})
+}
@@ -1,10 +1,6 @@
 package errors
 
-import (
-	"fmt"
-
-	"github.com/gofrs/uuid"
-)
+import "fmt"
 
 // derror is the internal error type used by this package.
 // Its name contains a d from decorated and for avoiding to clash with the
@@ -15,16 +11,32 @@ import (
 // to the instance of the error and the call stack.
 type derror struct {
 	c    Code
-	id   uuid.UUID
+	id   ID
 	mds  mDatas
 	werr error
 	cs   callStack
+	csr  csRole
 }
 
+// csRole is the role of the call stack of a derror when the call stacks of the
+// wrapped derrors are kept (see WithWrappedCallStacks).
+type csRole uint8
+
+const (
+	// csRoleNone is the role of a call stack which isn't kept with others.
+	csRoleNone csRole = iota
+	// csRoleStarted is the role of the call stack of the derror where the
+	// error started, it's the innermost one.
+	csRoleStarted
+	// csRoleWrapped is the role of the call stack of a derror which wraps a
+	// derror whose call stack is kept.
+	csRoleWrapped
+)
+
 // Format satisfies the fmt.Formatter interface.
 func (err derror) Format(state fmt.State, verb rune) {
 	if verb == 's' {
-		_, _ = fmt.Fprintf(state, "%s: %s", err.c.String(), err.c.Message())
+		_, _ = fmt.Fprintf(state, "%s: %s", err.c.String(), Message(err))
 		return
 	}
 
@@ -32,7 +44,7 @@ func (err derror) Format(state fmt.State, verb rune) {
 		return
 	}
 
-	_, _ = fmt.Fprintf(state, "%s: %s\n\tid: %s\n\tmetadata: %v", err.c.String(), err.c.Message(), err.id, err.mds)
+	_, _ = fmt.Fprintf(state, "%s: %s\n\tid: %s\n\tmetadata: %v", err.c.String(), Message(err), err.id, err.mds)
 	if !state.Flag('+') {
 		return
 	}
@@ -45,10 +57,25 @@ func (err derror) Format(state fmt.State, verb rune) {
 		return
 	}
 
+	var (
+		cs     = err.cs
+		header = "call stack"
+	)
+
+	switch err.csr {
+	case csRoleStarted:
+		header = "call stack (error started here)"
+	case csRoleWrapped:
+		header = "call stack (error wrapped here)"
+		if wde, ok := err.werr.(derror); ok {
+			cs = cs.diff(wde.cs)
+		}
+	}
+
 	if state.Flag('-') {
-		_, _ = fmt.Fprintf(state, "\n\tcall stack (compacted):\n%-v", err.cs)
+		_, _ = fmt.Fprintf(state, "\n\t%s (compacted):\n%-v", header, cs)
 	} else {
-		_, _ = fmt.Fprintf(state, "\n\tcall stack:\n%v", err.cs)
+		_, _ = fmt.Fprintf(state, "\n\t%s:\n%v", header, cs)
 	}
 }
 
@@ -57,3 +84,24 @@ func (err derror) Format(state fmt.State, verb rune) {
 func (err derror) Error() string {
 	return fmt.Sprintf("%s", err)
 }
+
+// Unwrap returns the error wrapped by err or nil if it doesn't wrap any.
+// It allows to use err with the standard library errors.Is, errors.As and
+// errors.Unwrap functions.
+func (err derror) Unwrap() error {
+	return err.werr
+}
+
+// Is returns true if target is an error value created by one of the
+// constructors of this package and its Code is the same than the err Code,
+// following the same rule than the package function Is, otherwise it returns
+// false.
+// It's used by the standard library errors.Is function.
+func (err derror) Is(target error) bool {
+	var t, ok = target.(derror)
+	if !ok {
+		return false
+	}
+
+	return equalCodes(err.c, t.c)
+}
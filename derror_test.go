@@ -3,10 +3,13 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDerror_Format(t *testing.T) {
@@ -94,3 +97,60 @@ func TestDerror_Error(t *testing.T) {
 	var err = New(testCode(true), MD{K: "var1", V: "a string"}, MD{K: "var2", V: 10})
 	assert.Equal(t, fmt.Sprintf("%s", err), err.Error())
 }
+
+func TestDerror_Unwrap(t *testing.T) {
+	t.Run("without wrapped error", func(t *testing.T) {
+		var err = New(testCode(true))
+		assert.Nil(t, errors.Unwrap(err))
+	})
+
+	t.Run("with wrapped error", func(t *testing.T) {
+		var (
+			extErr = errors.New("ext error: " + t.Name())
+			err    = Wrap(extErr, testCode(true))
+		)
+
+		assert.Equal(t, extErr, errors.Unwrap(err))
+		assert.True(t, errors.Is(err, extErr))
+	})
+
+	t.Run("with wrapped error deep in the chain", func(t *testing.T) {
+		var (
+			err = Wrap(
+				fmt.Errorf("reading config: %w", Wrap(io.EOF, differentTestCode(true))),
+				testCode(true),
+			)
+			perr = &os.PathError{Op: "open", Path: "/some/path", Err: io.ErrUnexpectedEOF}
+			err2 = Wrap(perr, testCode(true))
+			aerr *os.PathError
+		)
+
+		assert.True(t, errors.Is(err, io.EOF))
+		assert.False(t, errors.Is(err, io.ErrUnexpectedEOF))
+		require.True(t, errors.As(err2, &aerr))
+		assert.Equal(t, perr, aerr)
+		assert.True(t, errors.Is(err2, io.ErrUnexpectedEOF))
+	})
+}
+
+func TestDerror_Is(t *testing.T) {
+	t.Run("same error code", func(t *testing.T) {
+		var err = New(testCode(true))
+		assert.True(t, errors.Is(err, New(similarTestCode(true))))
+	})
+
+	t.Run("different error code", func(t *testing.T) {
+		var err = New(testCode(true))
+		assert.False(t, errors.Is(err, New(differentTestCode(true))))
+	})
+
+	t.Run("error code in the chain", func(t *testing.T) {
+		var err = Wrap(fmt.Errorf("ctx: %w", New(differentTestCode(true))), testCode(true))
+		assert.True(t, errors.Is(err, New(differentTestCode(true))))
+	})
+
+	t.Run("external error", func(t *testing.T) {
+		var err = New(testCode(true))
+		assert.False(t, errors.Is(err, errors.New(testCode(true).String())))
+	})
+}
@@ -66,14 +66,20 @@ because they could report it to the support team, a part of the code. Such ID
 could be helpful to provide a customized feedback/response when needed and for
 the operations team, could use the ID to correlate errors, when they are
 registered/tracked in different operational systems or, for any reason, in the
-same one several times.
+same one several times. By default, the IDs are time-ordered UUIDs, so they sort
+by their creation time, but they can be generated in other formats (see
+WithIDGenerator).
 
 3. Metadata. Despite that the code, and its associated message, should be
 precise, the operations team needs more information about what happened when the
 error has happened in some circumstances which aren't clear, for example the
 input parameter values, variable values, etc. Developers should have a way to
 provide such important context information when creating the errors and that's
-what, in this package, is called metadata.
+what, in this package, is called metadata. Metadata has a sensitivity: internal
+(the default) for the operations team and maintainers, public for what can be
+exposed to users (see PublicMD) and secret for what must not be exposed to
+anybody, as personal data, whose values are masked in any output of this
+package (see SecretMD).
 
 4. The call stack. Call stacks are ugly, but they provide the trace where the
 error was originated and such information is very useful for the operations team
@@ -108,6 +114,118 @@ however it only intentionally allows to get a part of it, because the
 information destined for operations is only thought to be exposed through
 systems for such purpose, for example logging.
 
+The error values returned by this package expose the error that they wrap, if
+any, so they can be used with the standard library errors.Is, errors.As and
+errors.Unwrap functions. errors.Is considers that two error values created by
+this package are the same when they have the same Code.
+
+
+Registering codes
+
+Codes are compared by their string representation and message, hence two
+packages could define different Codes which are considered the same. To avoid
+it, packages should register their Codes, usually in their init functions with
+MustRegister, in the process-wide Registry (see DefaultRegistry), which
+doesn't allow to register two Codes with the same string representation.
+
+A Registry also allows to obtain a Code from its string representation, which
+is needed to reconstruct the errors which are transmitted over the wire, and to
+list all the registered Codes, for example, for documenting them.
+
+The go.fraixed.es/errors/cmd/errcodegen command generates the Code types, their
+registration and their Markdown reference from a YAML or JSON catalog, which is
+handy for packages with many Codes.
+
+Hierarchy of codes
+
+Codes are organized in a hierarchy through the namespaces of their string
+representation, so "storage.NotFound" is under "storage", or through the
+Parent method of the Parenter interface, which has precedence. IsKindOf
+identifies the errors whose Code is any of the descendants of a Code, which can
+be a Namespace when there isn't a Code registered for it, without listing all
+of them, and the Tree method of a Registry returns the same hierarchy of the
+registered Codes.
+
+	if errors.IsKindOf(err, errors.Namespace("storage")) {
+		...
+	}
+
+Transmitting errors
+
+Errors can be transmitted over the wire through their JSON representation (see
+ToJSON) and reconstructed on the other side with FromJSON, which uses a
+Registry to find the original Codes, so the reconstructed error values can be
+identified with the functions of this package as the original ones.
+
+Aggregating errors
+
+Several errors can be aggregated in a single one with Join and Append, which is
+useful for returning all the errors which happen in batch operations or in
+validations. Is, GetCode, GetID, etc. consider the aggregated errors, and
+GetCodeFunc allows to choose the Code among them, for example, the most severe
+with MoreSevere.
+
+The go.fraixed.es/errors/validation package builds on it for collecting the
+violations of the fields of an input and the go.fraixed.es/errors/group package
+for collecting the failures of tasks which run concurrently.
+
+Severity and category
+
+Codes can declare the severity of the errors identified by them, satisfying
+the Severitier interface, and their category (client, server, dependency or
+security), satisfying the Categorizer interface, so the logging and metrics
+integrations can choose the log levels and alerts without hard-coding lists of
+Codes. Severity and Category return them for any error.
+
+	if s, ok := errors.Severity(err); ok {
+		logger.Log(ctx, s.Level(), "request failed", "error", err)
+	}
+
+Recovering from panics
+
+Recover turns a recovered panic into an error with a Code, holding the panic
+value as metadata and the call stack from where the goroutine panicked, and Go
+runs a function in a new goroutine doing the same with its panics.
+
+	func do() (err error) {
+		defer errors.Recover(CodeUnexpected, &err)
+		...
+	}
+
+Message templates
+
+The Codes can opt in to message templates satisfying the MessageTemplater
+interface, whose templates can refer to the metadata of the errors through
+placeholders, which are metadata keys enclosed by braces, and the braces are
+escaped by doubling them. The messages of the rest of the Codes are never
+considered templates.
+
+	func (c Code) Message() string {
+		return "user not found"
+	}
+
+	func (c Code) MessageTemplate() string {
+		return "user {user_id} not found"
+	}
+
+	err := errors.New(CodeUserNotFound, errors.MD{K: "user_id", V: 10})
+	fmt.Printf("%s", err) // UserNotFound: user 10 not found
+
+The placeholders are filled when the error is printed or logged and with the
+Message function; the placeholders whose keys aren't in the metadata are
+reported as "%!{key}(MISSING)". The messages exposed to the users, for example,
+the localized ones and the ones sent to HTTP and gRPC clients, are filled only
+with the public metadata through PublicMessage, which falls back to the message
+of the Code when the template cannot be filled. Is compares the messages
+without filling them, so all the errors with the same Code are still the same.
+The Registry rejects the Codes whose message templates are malformed.
+
+Localizing messages
+
+The messages of the Codes can be translated to other languages with the
+go.fraixed.es/errors/i18n module, which looks up the translations by the
+string representation of the Codes; the translations are message templates
+too.
 
 Printing the error
 
@@ -174,7 +292,45 @@ nothing.
 	//    runtime.goexit
 	//            /root/apps/go/src/runtime/asm_amd64.s:1333
 
+When an error, created by this package, is wrapped by Wrap, its call stack is
+discarded, unless the package is configured to keep it (see
+WithWrappedCallStacks); in such case, the call stack of the innermost error is
+printed as the place where the error started and the call stack of each error
+which wraps it only contains the frames which differ from the call stack of the
+error that it wraps.
+
+	fmt.Printf("%+v", err) // When err wraps another error and the call stacks are kept.
+	// Output
+	// TestCode: an test code error has happened
+	//    id: 018f3398-c100-7abc-9f3e-2cde8a3b1a0b
+	//    metadata: []
+	//    wrapped error: DifferentError: This is a different error
+	//    id: 018f3398-c0ff-7a12-8f3e-2cde8a3b1a0b
+	//    metadata: []
+	//    call stack (error started here):
+	//    go.fraixed.es/errors.TestDerror_Format.func1
+	//            /root/workspace/go-errors/derror_test.go:24
+	//    go.fraixed.es/errors.TestDerror_Format.func2
+	//            /root/workspace/go-errors/derror_test.go:31
+	//    go.fraixed.es/errors.TestDerror_Format
+	//            /root/workspace/go-errors/derror_test.go:36
+	//    testing.tRunner
+	//            /root/apps/go/src/testing/testing.go:827
+	//    runtime.goexit
+	//            /root/apps/go/src/runtime/asm_amd64.s:1333
+	//    call stack (error wrapped here):
+	//    go.fraixed.es/errors.TestDerror_Format.func2
+	//            /root/workspace/go-errors/derror_test.go:32
+
 Therefore, developers should consider to use one another depending the needs and
 the audience of those messages.
+
+Logging
+
+The error values returned by this package satisfy the slog.LogValuer interface,
+so they are logged by the log/slog package as a group of attributes rather than
+a single formatted string, which is more suitable for structured logs. The
+handlers returned by NewSlogHandler also allow to log the wrapped error and the
+call stack.
 */
 package errors
@@ -0,0 +1,17 @@
+// Command errorsvet reports misuses of the go.fraixed.es/errors package.
+// See the go.fraixed.es/errors/errorsvet package.
+//
+// It can run standalone or through go vet:
+//
+//	errorsvet ./...
+//	go vet -vettool=$(which errorsvet) ./...
+package main
+
+import (
+	"go.fraixed.es/errors/errorsvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(errorsvet.Analyzer)
+}
@@ -0,0 +1,27 @@
+/*
+Package errorsvet defines an analyzer which reports misuses of the
+go.fraixed.es/errors package.
+
+It reports:
+
+  - Errors created by the constructors of the package (New, Wrap, etc.) which
+    are discarded.
+  - Calls to Wrap and WrapSkip which wrap a nil error.
+  - Calls to Is, IsAny, HasCode and IsKindOf with Codes built inline rather
+    than declared constants or variables; the conversions to Namespace are
+    allowed.
+  - Metadata with duplicated keys in the same constructor call.
+  - Errors created with the standard errors.New or fmt.Errorf returned by
+    packages which have opted into coded errors, adding the directive
+    "//errors:coded" to any of their files.
+
+The analyzer can run standalone with the errorsvet command or through go vet.
+
+	go install go.fraixed.es/errors/errorsvet/cmd/errorsvet
+	errorsvet ./...
+	go vet -vettool=$(which errorsvet) ./...
+
+It's a separated module, so the go.fraixed.es/errors module doesn't depend on
+the golang.org/x/tools module.
+*/
+package errorsvet
@@ -0,0 +1,278 @@
+package errorsvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// pkgPath is the import path of the package whose use is analyzed.
+const pkgPath = "go.fraixed.es/errors"
+
+// CodedDirective is the directive that the packages add to any of their files
+// for opting into coded errors.
+const CodedDirective = "//errors:coded"
+
+// Analyzer reports misuses of the go.fraixed.es/errors package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorsvet",
+	Doc:      "report misuses of the go.fraixed.es/errors package",
+	URL:      "https://pkg.go.dev/go.fraixed.es/errors/errorsvet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// constructors are the functions of the package which create errors.
+var constructors = map[string]struct{}{
+	"New": {}, "NewSkip": {}, "Wrap": {}, "WrapSkip": {}, "Restore": {}, "Join": {}, "Append": {},
+}
+
+// wrapErrArg are the functions of the package which wrap an error and the
+// position of the argument with the wrapped error.
+var wrapErrArg = map[string]int{
+	"Wrap": 0, "WrapSkip": 1,
+}
+
+// codeArgs are the functions of the package which check the Code of an error
+// and the position of the first argument with a Code.
+var codeArgs = map[string]int{
+	"Is": 1, "IsAny": 1, "HasCode": 1, "IsKindOf": 1,
+}
+
+// mdFuncs are the functions of the package which return a metadata.
+var mdFuncs = map[string]struct{}{
+	"PublicMD": {}, "SecretMD": {},
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	var (
+		insp  = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+		coded = isCoded(pass)
+		nodes = []ast.Node{
+			(*ast.ExprStmt)(nil),
+			(*ast.AssignStmt)(nil),
+			(*ast.CallExpr)(nil),
+			(*ast.ReturnStmt)(nil),
+		}
+	)
+
+	insp.Preorder(nodes, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			if call, ok := ast.Unparen(n.X).(*ast.CallExpr); ok {
+				checkDiscarded(pass, call)
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return
+			}
+
+			for i, lhs := range n.Lhs {
+				var id, ok = lhs.(*ast.Ident)
+				if !ok || id.Name != "_" {
+					continue
+				}
+
+				if call, ok := ast.Unparen(n.Rhs[i]).(*ast.CallExpr); ok {
+					checkDiscarded(pass, call)
+				}
+			}
+		case *ast.CallExpr:
+			checkCall(pass, n)
+		case *ast.ReturnStmt:
+			if coded {
+				checkReturn(pass, n)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// pkgFunc returns the name of the function of the go.fraixed.es/errors package
+// called by call, or an empty string if it doesn't call any.
+func pkgFunc(pass *analysis.Pass, call *ast.CallExpr) string {
+	return funcOf(pass, call, pkgPath)
+}
+
+// funcOf returns the name of the package-level function of the package path
+// called by call, or an empty string if it doesn't call any.
+func funcOf(pass *analysis.Pass, call *ast.CallExpr, path string) string {
+	var fn, ok = typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != path {
+		return ""
+	}
+
+	if fn.Type().(*types.Signature).Recv() != nil {
+		return ""
+	}
+
+	return fn.Name()
+}
+
+// checkDiscarded reports call if it creates an error which is discarded.
+func checkDiscarded(pass *analysis.Pass, call *ast.CallExpr) {
+	var name = pkgFunc(pass, call)
+	if _, ok := constructors[name]; ok {
+		pass.ReportRangef(call, "the error created by errors.%s is discarded", name)
+	}
+}
+
+// checkCall reports the misuses of the arguments of call.
+func checkCall(pass *analysis.Pass, call *ast.CallExpr) {
+	var name = pkgFunc(pass, call)
+	if name == "" {
+		return
+	}
+
+	if i, ok := wrapErrArg[name]; ok && i < len(call.Args) && isNil(pass, call.Args[i]) {
+		pass.ReportRangef(call.Args[i], "errors.%s wraps a nil error", name)
+	}
+
+	if i, ok := codeArgs[name]; ok {
+		for _, arg := range call.Args[min(i, len(call.Args)):] {
+			if isInlineValue(pass, arg) {
+				pass.ReportRangef(arg, "errors.%s is called with a Code built inline, use a declared one", name)
+			}
+		}
+	}
+
+	if _, ok := constructors[name]; ok {
+		checkDuplicatedMD(pass, call)
+	}
+}
+
+// isNil returns true if e is the nil value.
+func isNil(pass *analysis.Pass, e ast.Expr) bool {
+	var tv, ok = pass.TypesInfo.Types[e]
+	return ok && tv.IsNil()
+}
+
+// isInlineValue returns true if e is a composite literal, a type conversion,
+// except to Namespace, or a constant literal.
+func isInlineValue(pass *analysis.Pass, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.CompositeLit, *ast.BasicLit:
+		return true
+	case *ast.UnaryExpr:
+		return e.Op == token.AND && isInlineValue(pass, e.X)
+	case *ast.CallExpr:
+		// The namespaces are meant to be converted inline because they aren't
+		// declared Codes.
+		var tv, ok = pass.TypesInfo.Types[e.Fun]
+		return ok && tv.IsType() && !isPkgType(tv.Type, "Namespace")
+	default:
+		return false
+	}
+}
+
+// isPkgType returns true if t is the type called name of the analyzed
+// package.
+func isPkgType(t types.Type, name string) bool {
+	var nt, ok = t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	var obj = nt.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// checkDuplicatedMD reports the metadata arguments of call whose keys are
+// duplicated; only the keys which are constants are considered.
+func checkDuplicatedMD(pass *analysis.Pass, call *ast.CallExpr) {
+	var keys = map[string]struct{}{}
+	for _, arg := range call.Args {
+		var kexpr = mdKey(pass, arg)
+		if kexpr == nil {
+			continue
+		}
+
+		var tv, ok = pass.TypesInfo.Types[kexpr]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			continue
+		}
+
+		var k = constant.StringVal(tv.Value)
+		if _, ok := keys[k]; ok {
+			pass.ReportRangef(arg, "metadata key %q is duplicated", k)
+			continue
+		}
+
+		keys[k] = struct{}{}
+	}
+}
+
+// mdKey returns the expression of the key of the metadata e, or nil if e isn't
+// a metadata composite literal or a call to a function which returns one.
+func mdKey(pass *analysis.Pass, e ast.Expr) ast.Expr {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.CompositeLit:
+		var named, ok = pass.TypesInfo.TypeOf(e).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != pkgPath || named.Obj().Name() != "MD" {
+			return nil
+		}
+
+		for i, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if id, ok := kv.Key.(*ast.Ident); ok && id.Name == "K" {
+					return kv.Value
+				}
+
+				continue
+			}
+
+			if i == 0 {
+				return elt
+			}
+		}
+	case *ast.CallExpr:
+		if _, ok := mdFuncs[pkgFunc(pass, e)]; ok && len(e.Args) > 0 {
+			return e.Args[0]
+		}
+	}
+
+	return nil
+}
+
+// isCoded returns true if any of the files of the package has the
+// CodedDirective.
+func isCoded(pass *analysis.Pass) bool {
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if strings.TrimSpace(c.Text) == CodedDirective {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// checkReturn reports the results of ret which are errors created with the
+// standard errors.New or fmt.Errorf.
+func checkReturn(pass *analysis.Pass, ret *ast.ReturnStmt) {
+	for _, r := range ret.Results {
+		var call, ok = ast.Unparen(r).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		if funcOf(pass, call, "errors") == "New" {
+			pass.ReportRangef(call, "the package uses coded errors, use go.fraixed.es/errors instead of errors.New")
+		}
+
+		if funcOf(pass, call, "fmt") == "Errorf" {
+			pass.ReportRangef(call, "the package uses coded errors, use go.fraixed.es/errors instead of fmt.Errorf")
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package errorsvet_test
+
+import (
+	"testing"
+
+	"go.fraixed.es/errors/errorsvet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), errorsvet.Analyzer, "a", "coded")
+}
@@ -0,0 +1,75 @@
+package a
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"go.fraixed.es/errors"
+)
+
+type code uint8
+
+const codeNotFound code = 1
+
+func (code) String() string  { return "a.Code" }
+func (code) Message() string { return "a code" }
+
+type structCode struct{ name string }
+
+func (structCode) String() string  { return "a.StructCode" }
+func (structCode) Message() string { return "a struct code" }
+
+var declaredStructCode = structCode{name: "declared"}
+
+const mdKey = "key"
+
+func discarded(err error) {
+	errors.Wrap(err, codeNotFound)            // want `the error created by errors.Wrap is discarded`
+	errors.New(codeNotFound)                  // want `the error created by errors.New is discarded`
+	_ = errors.WrapSkip(1, err, codeNotFound) // want `the error created by errors.WrapSkip is discarded`
+	_, _ = 1, errors.Join(err)                // want `the error created by errors.Join is discarded`
+
+	var werr = errors.Wrap(err, codeNotFound)
+	_ = werr
+}
+
+func wrapNil() error {
+	if true {
+		return errors.Wrap(nil, codeNotFound) // want `errors.Wrap wraps a nil error`
+	}
+
+	return errors.WrapSkip(1, nil, codeNotFound) // want `errors.WrapSkip wraps a nil error`
+}
+
+func inlineCodes(err error) bool {
+	return errors.Is(err, codeNotFound) ||
+		errors.Is(err, declaredStructCode) ||
+		errors.Is(err, code(2)) || // want `errors.Is is called with a Code built inline, use a declared one`
+		errors.Is(err, structCode{name: "inline"}) || // want `errors.Is is called with a Code built inline, use a declared one`
+		errors.IsAny(err, codeNotFound, &structCode{}) || // want `errors.IsAny is called with a Code built inline, use a declared one`
+		errors.HasCode(err, (code(3))) || // want `errors.HasCode is called with a Code built inline, use a declared one`
+		errors.IsKindOf(err, codeNotFound) ||
+		errors.IsKindOf(err, errors.Namespace("storage")) ||
+		errors.IsKindOf(err, code(4)) // want `errors.IsKindOf is called with a Code built inline, use a declared one`
+}
+
+func use(...error) {}
+
+func duplicatedMD(err error) error {
+	use(errors.New(codeNotFound, errors.MD{K: "id", V: 1}, errors.MD{K: "name", V: 2}))
+	use(errors.New(codeNotFound, errors.MD{K: "id", V: 1}, errors.MD{K: "id", V: 2}))   // want `metadata key "id" is duplicated`
+	use(errors.New(codeNotFound, errors.MD{"id", 1, 0}, errors.PublicMD("id", 2)))      // want `metadata key "id" is duplicated`
+	use(errors.Wrap(err, codeNotFound, errors.SecretMD(mdKey, 1), errors.MD{K: "key"})) // want `metadata key "key" is duplicated`
+
+	var k = "id"
+	return errors.Wrap(err, codeNotFound, errors.MD{K: k, V: 1}, errors.MD{K: k, V: 2})
+}
+
+// Packages which haven't opted into coded errors can return standard errors.
+func standard() error {
+	if true {
+		return stderrors.New("standard")
+	}
+
+	return fmt.Errorf("standard")
+}
@@ -0,0 +1,36 @@
+// Package coded has opted into coded errors.
+package coded
+
+//errors:coded
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"go.fraixed.es/errors"
+)
+
+type code uint8
+
+const codeNotFound code = 1
+
+func (code) String() string  { return "coded.Code" }
+func (code) Message() string { return "a code" }
+
+func get(id int) error {
+	switch id {
+	case 0:
+		return stderrors.New("not found") // want `the package uses coded errors, use go.fraixed.es/errors instead of errors.New`
+	case 1:
+		return fmt.Errorf("not found %d", id) // want `the package uses coded errors, use go.fraixed.es/errors instead of fmt.Errorf`
+	case 2:
+		var err = fmt.Errorf("not found %d", id)
+		return errors.Wrap(err, codeNotFound)
+	}
+
+	return errors.New(codeNotFound)
+}
+
+func find() (int, error) {
+	return 0, (stderrors.New("not found")) // want `the package uses coded errors, use go.fraixed.es/errors instead of errors.New`
+}
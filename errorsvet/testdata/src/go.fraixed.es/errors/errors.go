@@ -0,0 +1,50 @@
+// Package errors is a stub of the go.fraixed.es/errors package with the only
+// purpose of testing the analyzer.
+package errors
+
+type Code interface {
+	String() string
+	Message() string
+}
+
+type ID string
+
+type Sensitivity uint8
+
+type MD struct {
+	K string
+	V interface{}
+	S Sensitivity
+}
+
+func PublicMD(k string, v interface{}) MD { return MD{K: k, V: v} }
+
+func SecretMD(k string, v interface{}) MD { return MD{K: k, V: v} }
+
+func New(c Code, mds ...MD) error { return nil }
+
+func NewSkip(skip int, c Code, mds ...MD) error { return nil }
+
+func Wrap(err error, c Code, mds ...MD) error { return nil }
+
+func WrapSkip(skip int, err error, c Code, mds ...MD) error { return nil }
+
+func Restore(c Code, id ID, werr error, mds ...MD) error { return nil }
+
+func Join(errs ...error) error { return nil }
+
+func Append(err error, errs ...error) error { return nil }
+
+func Is(err error, c Code) bool { return false }
+
+func IsAny(err error, cs ...Code) bool { return false }
+
+func HasCode(err error, c Code) bool { return false }
+
+func IsKindOf(err error, parent Code) bool { return false }
+
+type Namespace string
+
+func (n Namespace) String() string { return string(n) }
+
+func (n Namespace) Message() string { return string(n) }
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Frame is a frame of the call stack captured when an error is created.
+type Frame struct {
+	// Function is the package path-qualified function name, for example
+	// "go.fraixed.es/errors.New".
+	Function string
+	// Package is the import path of the package of the function, for example
+	// "go.fraixed.es/errors".
+	Package string
+	// File is the absolute path of the source file.
+	File string
+	// Line is the line number in File.
+	Line int
+}
+
+// StackTrace returns the call stack frames of the first error value, created
+// by one of the constructors of this package, found in the err chain, being
+// the first frame the place where the error was created; it returns nil if
+// there isn't any or it doesn't have call stack.
+func StackTrace(err error) []Frame {
+	return StackTraceAt(err, 0)
+}
+
+// StackTraceAt is like StackTrace but it returns the call stack frames of the
+// error value, created by one of the constructors of this package, found in
+// the layer position of the err chain, being 0 the first one; it returns nil
+// if there isn't any in such position or it doesn't have call stack.
+// Only the error values created by one of the constructors of this package
+// are considered layers of the chain.
+func StackTraceAt(err error, layer int) []Frame {
+	var (
+		frs []Frame
+		i   int
+	)
+
+	walk(err, func(derr derror) bool {
+		if i < layer {
+			i++
+			return true
+		}
+
+		frs = derr.cs.frames()
+		return false
+	})
+
+	return frs
+}
+
+// frames returns the frames of cs or nil if it's empty.
+func (cs callStack) frames() []Frame {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	var (
+		rfrs = runtime.CallersFrames(cs)
+		frs  = make([]Frame, 0, len(cs))
+	)
+
+	for {
+		var f, more = rfrs.Next()
+		frs = append(frs, Frame{
+			Function: f.Function,
+			Package:  funcPackage(f.Function),
+			File:     f.File,
+			Line:     f.Line,
+		})
+
+		if !more {
+			break
+		}
+	}
+
+	return frs
+}
+
+// funcPackage returns the import path of the package of the package
+// path-qualified function name fn.
+// The runtime escapes the dots of the last element of the import path, so they
+// are unescaped.
+func funcPackage(fn string) string {
+	var (
+		slash = strings.LastIndex(fn, "/")
+		dot   = strings.Index(fn[slash+1:], ".")
+	)
+
+	if dot < 0 {
+		return ""
+	}
+
+	return strings.Replace(fn[:slash+1+dot], "%2e", ".", -1)
+}
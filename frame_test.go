@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackTrace(t *testing.T) {
+	t.Run("created by this package", func(t *testing.T) {
+		var (
+			err = fmt.Errorf("some context: %w", New(testCode(true)))
+			frs = StackTrace(err)
+		)
+
+		require.NotEmpty(t, frs)
+		assert.Equal(t, "go.fraixed.es/errors.TestStackTrace.func1", frs[0].Function)
+		assert.Equal(t, "go.fraixed.es/errors", frs[0].Package)
+		assert.True(t, strings.HasSuffix(frs[0].File, "frame_test.go"))
+		assert.Equal(t, 16, frs[0].Line)
+
+		assert.Equal(t, "testing.tRunner", frs[1].Function)
+		assert.Equal(t, "testing", frs[1].Package)
+	})
+
+	t.Run("without call stack", func(t *testing.T) {
+		var err = Restore(testCode(true), ID("id"), nil)
+		assert.Nil(t, StackTrace(err))
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		assert.Nil(t, StackTrace(errors.New("some error")))
+	})
+}
+
+func TestStackTraceAt(t *testing.T) {
+	var (
+		inner = errors.Join(errors.New("some error"), New(differentTestCode(true)))
+		err   = Wrap(fmt.Errorf("some context: %w", inner), testCode(true))
+	)
+
+	var frs = StackTraceAt(err, 0)
+	require.NotEmpty(t, frs)
+	assert.Equal(t, StackTrace(err), frs)
+
+	frs = StackTraceAt(err, 1)
+	require.NotEmpty(t, frs)
+	assert.Equal(t, "go.fraixed.es/errors.TestStackTraceAt", frs[0].Function)
+	assert.Equal(t, 42, frs[0].Line)
+
+	assert.Nil(t, StackTraceAt(err, 2))
+}
+
+func TestFuncPackage(t *testing.T) {
+	var tcases = []struct {
+		fn     string
+		exppkg string
+	}{
+		{fn: "go.fraixed.es/errors.New", exppkg: "go.fraixed.es/errors"},
+		{fn: "go.fraixed.es/errors.(*Registry).Register", exppkg: "go.fraixed.es/errors"},
+		{fn: "go.fraixed.es/errors.TestX.func1.2", exppkg: "go.fraixed.es/errors"},
+		{fn: "main.main", exppkg: "main"},
+		{fn: "runtime.goexit", exppkg: "runtime"},
+		{fn: "gopkg.in/yaml%2ev3.Unmarshal", exppkg: "gopkg.in/yaml.v3"},
+		{fn: "unknown", exppkg: ""},
+	}
+
+	for _, tc := range tcases {
+		assert.Equal(t, tc.exppkg, funcPackage(tc.fn), tc.fn)
+	}
+}
@@ -1,8 +1,9 @@
 module go.fraixed.es/errors
 
+go 1.21
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/gofrs/uuid v3.1.0+incompatible
 	github.com/mattn/goveralls v0.0.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.2.2
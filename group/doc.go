@@ -0,0 +1,28 @@
+/*
+Package group runs tasks concurrently, like golang.org/x/sync/errgroup does,
+collecting their failures as errors created by the go.fraixed.es/errors
+package.
+
+Each task has a name and a Code; when a task fails, the error that it returns,
+or the panic that it raises, is wrapped in an error with its Code and its name
+as metadata (see TaskMDKey). Wait returns an aggregate of those errors (see
+errors.Join), so errors.Is, errors.GetCode, etc. consider all of them.
+
+	g, ctx := group.WithContext(ctx, group.WithLimit(4))
+	for _, u := range urls {
+		u := u
+		g.Go(u, CodeFetch, func(ctx context.Context) error {
+			return fetch(ctx, u)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+A group works in one of two modes: in fail fast mode (the default), the first
+failure cancels the group context, the tasks which haven't started are skipped
+and Wait only returns the first failure; in collect all mode, the failures
+don't cancel the group context and Wait returns all of them.
+*/
+package group
@@ -0,0 +1,166 @@
+package group
+
+import (
+	"context"
+	"sync"
+
+	"go.fraixed.es/errors"
+)
+
+// TaskMDKey is the key of the metadata which holds the name of the task in the
+// errors of the failed tasks.
+const TaskMDKey = "task"
+
+// Mode indicates how a Group deals with the failures of its tasks.
+type Mode uint8
+
+const (
+	// ModeFailFast cancels the group context on the first failure, skips the
+	// tasks which haven't started and only reports the first failure.
+	ModeFailFast Mode = iota
+	// ModeCollectAll runs all the tasks and reports all the failures, without
+	// canceling the group context until Wait returns.
+	ModeCollectAll
+)
+
+// Option is a function which modifies the configuration of a Group. See
+// WithContext.
+type Option func(*Group)
+
+// WithMode sets the mode of the Group. ModeFailFast is the default.
+func WithMode(m Mode) Option {
+	return func(g *Group) {
+		g.mode = m
+	}
+}
+
+// WithLimit limits the number of tasks which run concurrently to n; n lower
+// than 1 means no limit, which is the default.
+func WithLimit(n int) Option {
+	return func(g *Group) {
+		if n < 1 {
+			g.sem = nil
+			return
+		}
+
+		g.sem = make(chan struct{}, n)
+	}
+}
+
+// Group is a collection of tasks which run concurrently.
+// A Group must be created with WithContext and it's safe for concurrent use.
+type Group struct {
+	mode   Mode
+	sem    chan struct{}
+	cancel context.CancelFunc
+	ctx    context.Context
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	failed bool
+	errs   []error
+}
+
+// WithContext creates a Group configured with opts and returns it with a
+// context derived from ctx, which is passed to the tasks and it's canceled when
+// the first task fails, in fail fast mode, or when Wait returns.
+func WithContext(ctx context.Context, opts ...Option) (*Group, context.Context) {
+	var g = &Group{}
+	for _, o := range opts {
+		o(g)
+	}
+
+	g.ctx, g.cancel = context.WithCancel(ctx)
+	return g, g.ctx
+}
+
+// Go runs fn, in a new goroutine, as the task name identified by c.
+// If fn returns an error or panics, the task fails with an error with c, mds
+// and the task name as metadata, wrapping the error returned by fn or the
+// error created from the panic (see errors.Recover).
+//
+// When the Group has a limit, Go blocks until the task can run. In fail fast
+// mode, the task doesn't run if another task has already failed.
+//
+// Go must be called before Wait or by the tasks of the Group.
+func (g *Group) Go(name string, c errors.Code, fn func(ctx context.Context) error, mds ...errors.MD) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	if g.mode == ModeFailFast && g.hasFailed() {
+		if g.sem != nil {
+			<-g.sem
+		}
+
+		return
+	}
+
+	var tmds = make([]errors.MD, 0, len(mds)+1)
+	tmds = append(tmds, errors.MD{K: TaskMDKey, V: name})
+	tmds = append(tmds, mds...)
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := g.run(c, fn, tmds); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// Wait blocks until all the tasks have finished and returns an aggregate of
+// the errors of the failed tasks, in the order that they failed, or nil if
+// none failed. In fail fast mode, it only has the error of the first failed
+// task.
+// It cancels the group context before returning.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return errors.Join(g.errs...)
+}
+
+// run runs fn and returns nil if it succeeds, otherwise the error of the
+// failed task.
+func (g *Group) run(c errors.Code, fn func(ctx context.Context) error, mds []errors.MD) (err error) {
+	defer errors.Recover(c, &err, mds...)
+
+	if err := fn(g.ctx); err != nil {
+		return errors.Wrap(err, c, mds...)
+	}
+
+	return nil
+}
+
+// fail registers err as the error of a failed task.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.mode == ModeFailFast {
+		if g.failed {
+			return
+		}
+
+		g.cancel()
+	}
+
+	g.failed = true
+	g.errs = append(g.errs, err)
+}
+
+// hasFailed returns true if any task has failed.
+func (g *Group) hasFailed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.failed
+}
@@ -0,0 +1,147 @@
+package group
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+)
+
+func TestGroup_collectAll(t *testing.T) {
+	var (
+		g, ctx = WithContext(context.Background(), WithMode(ModeCollectAll))
+		errIO  = fmt.Errorf("io error")
+		ran    int32
+	)
+
+	g.Go("ok", taskTestCode(true), func(context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	})
+	g.Go("io", taskTestCode(true), func(context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return errIO
+	}, errors.MD{K: "file", V: "a.txt"})
+	g.Go("panic", otherTaskTestCode(true), func(context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		panic("boom")
+	})
+
+	var err = g.Wait()
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&ran))
+	assert.Error(t, ctx.Err(), "context is canceled when Wait returns")
+
+	assert.True(t, errors.Is(err, taskTestCode(true)))
+	assert.True(t, errors.Is(err, otherTaskTestCode(true)))
+	assert.True(t, stderrors.Is(err, errIO))
+
+	var merr, ok = err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+	require.Len(t, merr.Unwrap(), 2)
+
+	var tasks = map[string]error{}
+	for _, e := range merr.Unwrap() {
+		var v, _ = errors.LookupMD(e, TaskMDKey)
+		tasks[v.(string)] = e
+	}
+
+	require.Contains(t, tasks, "io")
+	var v, _ = errors.LookupMD(tasks["io"], "file")
+	assert.Equal(t, "a.txt", v)
+	var c, _ = errors.GetCode(tasks["io"])
+	assert.Equal(t, taskTestCode(true).String(), c.String())
+
+	require.Contains(t, tasks, "panic")
+	v, _ = errors.LookupMD(tasks["panic"], errors.PanicMDKey)
+	assert.Equal(t, "boom", v)
+	c, _ = errors.GetCode(tasks["panic"])
+	assert.Equal(t, otherTaskTestCode(true).String(), c.String())
+}
+
+func TestGroup_failFast(t *testing.T) {
+	var g, ctx = WithContext(context.Background())
+
+	g.Go("waiting", taskTestCode(true), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go("failing", otherTaskTestCode(true), func(context.Context) error {
+		return fmt.Errorf("failure")
+	})
+
+	var err = g.Wait()
+	require.Error(t, err)
+	assert.Error(t, ctx.Err())
+
+	// Only the error of the first failed task is reported.
+	var merr, ok = err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+	require.Len(t, merr.Unwrap(), 1)
+
+	var v, _ = errors.LookupMD(err, TaskMDKey)
+	assert.Equal(t, "failing", v)
+	assert.True(t, errors.Is(err, otherTaskTestCode(true)))
+	assert.False(t, errors.HasCode(err, taskTestCode(true)))
+}
+
+func TestGroup_failFast_skipsTasks(t *testing.T) {
+	var g, ctx = WithContext(context.Background())
+
+	g.Go("failing", taskTestCode(true), func(context.Context) error {
+		return fmt.Errorf("failure")
+	})
+
+	<-ctx.Done()
+
+	var ran bool
+	g.Go("skipped", taskTestCode(true), func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	var err = g.Wait()
+	assert.False(t, ran)
+
+	var v, _ = errors.LookupMD(err, TaskMDKey)
+	assert.Equal(t, "failing", v)
+}
+
+func TestGroup_limit(t *testing.T) {
+	var (
+		g, _    = WithContext(context.Background(), WithLimit(2), WithMode(ModeCollectAll))
+		running int32
+		max     int32
+	)
+
+	for i := 0; i < 10; i++ {
+		g.Go(fmt.Sprintf("task-%d", i), taskTestCode(true), func(context.Context) error {
+			var n = atomic.AddInt32(&running, 1)
+			for {
+				var m = atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+
+	assert.NoError(t, g.Wait())
+	assert.True(t, atomic.LoadInt32(&max) <= 2, "max concurrent tasks: %d", max)
+}
+
+func TestGroup_noTasks(t *testing.T) {
+	var g, ctx = WithContext(context.Background(), WithLimit(0))
+	assert.NoError(t, g.Wait())
+	assert.Error(t, ctx.Err())
+}
@@ -0,0 +1,25 @@
+package group
+
+// taskTestCode is a silly example of a Code implementation with the only
+// purpose of testing this package.
+type taskTestCode bool
+
+func (taskTestCode) String() string {
+	return "TaskTestCode"
+}
+
+func (taskTestCode) Message() string {
+	return "the test task has failed"
+}
+
+// otherTaskTestCode is a silly example of a Code implementation with the only
+// purpose of testing this package.
+type otherTaskTestCode bool
+
+func (otherTaskTestCode) String() string {
+	return "OtherTaskTestCode"
+}
+
+func (otherTaskTestCode) Message() string {
+	return "the other test task has failed"
+}
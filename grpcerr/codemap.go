@@ -0,0 +1,87 @@
+package grpcerr
+
+import (
+	"sync"
+
+	"go.fraixed.es/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// CodeMap maps Codes to gRPC codes overriding the ones returned by the Codes
+// which satisfy the Coder interface. It allows to map Codes defined by third
+// party packages.
+// A CodeMap is safe for concurrent use.
+type CodeMap struct {
+	mu    sync.RWMutex
+	codes map[string]codes.Code
+}
+
+// NewCodeMap creates an empty CodeMap.
+func NewCodeMap() *CodeMap {
+	return &CodeMap{codes: map[string]codes.Code{}}
+}
+
+// Set sets gc as the gRPC code of the errors identified by c, or by any other
+// Code with the same string representation.
+func (m *CodeMap) Set(c errors.Code, gc codes.Code) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.codes[c.String()] = gc
+}
+
+// Code returns the gRPC code of err, which is the one of the first error
+// value, created by one of the constructors of the go.fraixed.es/errors
+// package, found in the err chain whose Code has a gRPC code set in m (see
+// Set) or satisfies the Coder interface, with a gRPC code other than codes.OK;
+// the one set in m has precedence.
+// When there isn't any, it returns codes.Unknown.
+func (m *CodeMap) Code(err error) codes.Code {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// The first Code which has a gRPC code is chosen.
+	var c, ok = errors.GetCodeFunc(err, func(c, than errors.Code) bool {
+		if _, ok := m.lookup(than); ok {
+			return false
+		}
+
+		var _, ok = m.lookup(c)
+		return ok
+	})
+	if !ok {
+		return codes.Unknown
+	}
+
+	if gc, ok := m.lookup(c); ok {
+		return gc
+	}
+
+	return codes.Unknown
+}
+
+// lookup returns the gRPC code of c and true; if c doesn't have any, false is
+// returned and the gRPC code can be ignored.
+func (m *CodeMap) lookup(c errors.Code) (codes.Code, bool) {
+	if gc, ok := m.codes[c.String()]; ok {
+		return gc, true
+	}
+
+	if gc, ok := c.(Coder); ok {
+		if gcc := gc.GRPCCode(); gcc != codes.OK {
+			return gcc, true
+		}
+	}
+
+	return codes.Unknown, false
+}
+
+// defaultCodeMap is the process-wide CodeMap used by the Converters which don't
+// have any.
+var defaultCodeMap = NewCodeMap()
+
+// SetCode sets gc as the gRPC code of the errors identified by c in the
+// process-wide CodeMap. See CodeMap.Set.
+func SetCode(c errors.Code, gc codes.Code) {
+	defaultCodeMap.Set(c, gc)
+}
@@ -0,0 +1,92 @@
+package grpcerr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.fraixed.es/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCodeMap_Code(t *testing.T) {
+	var m = NewCodeMap()
+	m.Set(testCode(true), codes.InvalidArgument)
+
+	var tcases = []struct {
+		desc string
+		err  error
+		exp  codes.Code
+	}{
+		{
+			desc: "code set in the map",
+			err:  errors.New(testCode(true)),
+			exp:  codes.InvalidArgument,
+		},
+		{
+			desc: "code satisfying Coder",
+			err:  errors.New(notFoundTestCode(true)),
+			exp:  codes.NotFound,
+		},
+		{
+			desc: "wrapped code",
+			err:  errors.Wrap(fmt.Errorf("context: %w", errors.New(notFoundTestCode(true))), tmplTestCode(true)),
+			exp:  codes.NotFound,
+		},
+		{
+			desc: "the first code has precedence",
+			err:  errors.Wrap(errors.New(notFoundTestCode(true)), testCode(true)),
+			exp:  codes.InvalidArgument,
+		},
+		{
+			desc: "aggregate",
+			err:  errors.Join(errors.New(tmplTestCode(true)), errors.New(notFoundTestCode(true))),
+			exp:  codes.NotFound,
+		},
+		{
+			desc: "code satisfying Coder without gRPC code",
+			err:  errors.New(okTestCode(true)),
+			exp:  codes.Unknown,
+		},
+		{
+			desc: "code satisfying Coder without gRPC code wrapping code with it",
+			err:  errors.Wrap(errors.New(notFoundTestCode(true)), okTestCode(true)),
+			exp:  codes.NotFound,
+		},
+		{
+			desc: "without gRPC code",
+			err:  errors.New(tmplTestCode(true)),
+			exp:  codes.Unknown,
+		},
+		{
+			desc: "created by other package",
+			err:  fmt.Errorf("some error"),
+			exp:  codes.Unknown,
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.exp, m.Code(tc.err))
+		})
+	}
+
+	t.Run("override", func(t *testing.T) {
+		var m = NewCodeMap()
+		m.Set(notFoundTestCode(true), codes.FailedPrecondition)
+		assert.Equal(t, codes.FailedPrecondition, m.Code(errors.New(notFoundTestCode(true))))
+	})
+}
+
+func TestConverter_CodeMap(t *testing.T) {
+	var m = NewCodeMap()
+	m.Set(testCode(true), codes.PermissionDenied)
+
+	var err = Converter{CodeMap: m}.ToError(errors.New(testCode(true)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	err = ToError(errors.New(testCode(true)))
+	assert.Equal(t, codes.Unknown, status.Code(err))
+}
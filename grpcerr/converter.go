@@ -0,0 +1,225 @@
+package grpcerr
+
+import (
+	"fmt"
+	"sort"
+
+	"go.fraixed.es/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultDomain is the ErrorInfo domain used when the Converter doesn't have
+// any.
+const DefaultDomain = "go.fraixed.es/errors"
+
+const (
+	// idKey is the ErrorInfo metadata key which holds the error ID.
+	idKey = "id"
+	// messageKey is the ErrorInfo metadata key which holds the message of the
+	// Code when the status message is different.
+	messageKey = "message"
+	// domainField is the Struct field which holds the domain, which identifies
+	// the Struct details which hold the public metadata.
+	domainField = "domain"
+	// metadataField is the Struct field which holds the public metadata.
+	metadataField = "metadata"
+)
+
+// Coder is the interface that the Codes can optionally satisfy for indicating
+// the gRPC code which corresponds to the errors identified by them.
+// GRPCCode returns codes.OK when the Code doesn't have any, so it's considered
+// as if it didn't satisfy this interface.
+type Coder interface {
+	GRPCCode() codes.Code
+}
+
+// Converter converts errors to gRPC statuses and vice versa.
+// The zero value is ready to use.
+type Converter struct {
+	// Domain is the domain of the ErrorInfo status details. The statuses whose
+	// ErrorInfo has a different domain aren't converted to errors. When it's
+	// empty, DefaultDomain is used.
+	Domain string
+	// Registry is used for obtaining the Codes of the converted statuses. When
+	// it's nil, the default registry is used.
+	Registry *errors.Registry
+	// CodeMap is used for obtaining the gRPC codes of the errors. When it's
+	// nil, the process-wide CodeMap is used (see SetCode).
+	CodeMap *CodeMap
+}
+
+// Status returns the gRPC status of err. err must be created by one of the
+// constructors of the go.fraixed.es/errors package, otherwise it returns
+// false and the status can be ignored.
+// The status message is the message template of the Code of err filled with
+// its public metadata (see errors.PublicCodeMessage), which are also added
+// as a status detail. Only the metadata of the first error value, created by
+// one of the constructors of the go.fraixed.es/errors package, found in the
+// err chain is considered (see errors.GetMD), so the wrapped errors are never
+// exposed.
+func (c Converter) Status(err error) (*status.Status, bool) {
+	var code, ok = errors.GetCode(err)
+	if !ok {
+		return nil, false
+	}
+
+	var (
+		id, _ = errors.GetID(err)
+		mds   = errors.GetMD(err)
+		msg   = errors.PublicCodeMessage(code, mds...)
+		st    = status.New(c.codeMap().Code(err), msg)
+		info  = &errdetails.ErrorInfo{
+			Reason:   code.String(),
+			Domain:   c.domain(),
+			Metadata: map[string]string{idKey: id.String()},
+		}
+		fields = map[string]*structpb.Value{}
+	)
+
+	if msg != code.Message() {
+		info.Metadata[messageKey] = code.Message()
+	}
+
+	for _, md := range errors.PublicMDs(mds) {
+		var v, verr = structpb.NewValue(md.V)
+		if verr != nil {
+			v = structpb.NewStringValue(fmt.Sprintf("%+v", md.V))
+		}
+
+		fields[md.K] = v
+	}
+
+	var (
+		dst  *status.Status
+		derr error
+	)
+	if len(fields) > 0 {
+		dst, derr = st.WithDetails(info, &structpb.Struct{Fields: map[string]*structpb.Value{
+			domainField:   structpb.NewStringValue(c.domain()),
+			metadataField: structpb.NewStructValue(&structpb.Struct{Fields: fields}),
+		}})
+	} else {
+		dst, derr = st.WithDetails(info)
+	}
+
+	if derr != nil {
+		// It only happens if the details cannot be marshalled, which should
+		// never happen with these messages.
+		return st, true
+	}
+
+	return dst, true
+}
+
+// ToError returns err converted to an error created from its gRPC status (see
+// Status), which can be returned by the gRPC handlers. When err isn't created
+// by one of the constructors of the go.fraixed.es/errors package, it returns
+// err.
+func (c Converter) ToError(err error) error {
+	var st, ok = c.Status(err)
+	if !ok {
+		return err
+	}
+
+	return st.Err()
+}
+
+// FromError returns the error reconstructed from the gRPC status of err, which
+// has the Code, ID and public metadata of the original error and wraps err,
+// so the gRPC status can still be obtained from it.
+// When err doesn't have a gRPC status which was created by a Converter with
+// the same domain, it returns err.
+func (c Converter) FromError(err error) error {
+	var st, ok = status.FromError(err)
+	if !ok || st == nil {
+		return err
+	}
+
+	var (
+		info *errdetails.ErrorInfo
+		mdst *structpb.Struct
+	)
+
+	for _, d := range st.Details() {
+		switch dv := d.(type) {
+		case *errdetails.ErrorInfo:
+			if dv.GetDomain() == c.domain() {
+				info = dv
+			}
+		case *structpb.Struct:
+			if dv.GetFields()[domainField].GetStringValue() == c.domain() {
+				mdst = dv.GetFields()[metadataField].GetStructValue()
+			}
+		}
+	}
+
+	if info == nil {
+		return err
+	}
+
+	var id = errors.ID(info.GetMetadata()[idKey])
+	if id == "" {
+		return err
+	}
+
+	var (
+		mdvs = mdst.AsMap()
+		keys = make([]string, 0, len(mdvs))
+	)
+
+	for k := range mdvs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var mds = make([]errors.MD, len(keys))
+	for i, k := range keys {
+		mds[i] = errors.PublicMD(k, mdvs[k])
+	}
+
+	var msg = info.GetMetadata()[messageKey]
+	if msg == "" {
+		msg = st.Message()
+	}
+
+	return errors.Restore(c.registry().Resolve(info.GetReason(), msg), id, err, mds...)
+}
+
+func (c Converter) domain() string {
+	if c.Domain == "" {
+		return DefaultDomain
+	}
+
+	return c.Domain
+}
+
+func (c Converter) codeMap() *CodeMap {
+	if c.CodeMap == nil {
+		return defaultCodeMap
+	}
+
+	return c.CodeMap
+}
+
+func (c Converter) registry() *errors.Registry {
+	if c.Registry == nil {
+		return errors.DefaultRegistry()
+	}
+
+	return c.Registry
+}
+
+// ToError converts err using the zero value Converter. See Converter.ToError.
+func ToError(err error) error {
+	return Converter{}.ToError(err)
+}
+
+// FromError converts err using the zero value Converter. See
+// Converter.FromError.
+func FromError(err error) error {
+	return Converter{}.FromError(err)
+}
@@ -0,0 +1,197 @@
+package grpcerr
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestConverter_Status(t *testing.T) {
+	var c = Converter{Domain: "test.example.com"}
+
+	t.Run("created by the errors package", func(t *testing.T) {
+		var (
+			err = errors.Wrap(
+				fmt.Errorf("some context: %w", errors.New(notFoundTestCode(true), errors.PublicMD("inner", 1))),
+				testCode(true),
+				errors.PublicMD("user_id", "abc"), errors.MD{K: "query", V: "SELECT 1"},
+				errors.SecretMD("email", "ivan@example.com"),
+			)
+			id, _  = errors.GetID(err)
+			st, ok = c.Status(err)
+		)
+		require.True(t, ok)
+
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, testCode(true).Message(), st.Message())
+
+		var details = st.Details()
+		require.Len(t, details, 2)
+
+		var info = details[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, "TestCode", info.GetReason())
+		assert.Equal(t, "test.example.com", info.GetDomain())
+		assert.Equal(t, map[string]string{"id": id.String()}, info.GetMetadata())
+
+		var mds = details[1].(*structpb.Struct)
+		assert.Equal(t, map[string]interface{}{
+			"domain":   "test.example.com",
+			"metadata": map[string]interface{}{"user_id": "abc"},
+		}, mds.AsMap())
+	})
+
+	t.Run("without public metadata", func(t *testing.T) {
+		var st, ok = c.Status(errors.New(testCode(true), errors.MD{K: "query", V: "SELECT 1"}))
+		require.True(t, ok)
+
+		assert.Equal(t, codes.Unknown, st.Code())
+		assert.Len(t, st.Details(), 1)
+	})
+
+	t.Run("message template", func(t *testing.T) {
+		var (
+			err    = errors.New(tmplTestCode(true), errors.PublicMD("item_id", 5), errors.MD{K: "db", V: "x"})
+			id, _  = errors.GetID(err)
+			st, ok = c.Status(err)
+		)
+		require.True(t, ok)
+
+		assert.Equal(t, "the test item 5 doesn't exist", st.Message())
+
+		var info = st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t,
+			map[string]string{"id": id.String(), "message": "the test item doesn't exist"},
+			info.GetMetadata(),
+		)
+
+		st, _ = c.Status(errors.New(tmplTestCode(true), errors.MD{K: "item_id", V: 5}))
+		assert.Equal(t, "the test item doesn't exist", st.Message())
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		var _, ok = c.Status(stderrors.New("some error"))
+		assert.False(t, ok)
+	})
+}
+
+func TestConverter_FromError(t *testing.T) {
+	var (
+		r = errors.NewRegistry()
+		c = Converter{Registry: r}
+	)
+	r.MustRegister(testCode(true))
+
+	t.Run("round trip", func(t *testing.T) {
+		var (
+			err = errors.New(testCode(true),
+				errors.PublicMD("user_id", "abc"), errors.PublicMD("fn", func() {}), errors.MD{K: "query", V: "q"},
+			)
+			serr = c.ToError(err)
+			derr = c.FromError(serr)
+		)
+
+		var code, _ = errors.GetCode(derr)
+		assert.Equal(t, testCode(true), code)
+
+		var id, _ = errors.GetID(derr)
+		var expid, _ = errors.GetID(err)
+		assert.Equal(t, expid, id)
+
+		assert.Len(t, errors.GetMD(derr), 2)
+		var v, _ = errors.LookupMD(derr, "user_id")
+		assert.Equal(t, "abc", v)
+		var _, ok = errors.LookupMD(derr, "query")
+		assert.False(t, ok)
+
+		assert.Equal(t, codes.Unknown, status.Code(derr))
+	})
+
+	t.Run("code isn't registered", func(t *testing.T) {
+		var (
+			derr    = c.FromError(c.ToError(errors.New(notFoundTestCode(true))))
+			code, _ = errors.GetCode(derr)
+		)
+
+		assert.Equal(t, "NotFoundTestCode", code.String())
+		assert.Equal(t, "the test resource doesn't exist", code.Message())
+		assert.Equal(t, codes.NotFound, status.Code(derr))
+	})
+
+	t.Run("code with message template isn't registered", func(t *testing.T) {
+		var (
+			err     = errors.New(tmplTestCode(true), errors.PublicMD("item_id", 5))
+			derr    = c.FromError(c.ToError(err))
+			code, _ = errors.GetCode(derr)
+		)
+
+		assert.Equal(t, "the test item doesn't exist", code.Message())
+		assert.True(t, errors.Is(derr, tmplTestCode(true)))
+	})
+
+	t.Run("status from other domain", func(t *testing.T) {
+		var (
+			serr = Converter{Domain: "other"}.ToError(errors.New(testCode(true)))
+			err  = c.FromError(serr)
+		)
+
+		assert.Equal(t, serr, err)
+		var _, ok = errors.GetCode(err)
+		assert.False(t, ok)
+	})
+
+	t.Run("struct details from other domain", func(t *testing.T) {
+		var (
+			other, _ = structpb.NewStruct(map[string]interface{}{"user_id": "other"})
+			st, _    = c.Status(errors.New(testCode(true), errors.PublicMD("user_id", "abc")))
+			pst      = st.Proto()
+		)
+
+		// The struct of other domain is added before the one of the converter.
+		var oany, _ = anypb.New(other)
+		pst.Details = []*anypb.Any{pst.Details[0], oany, pst.Details[1]}
+
+		var (
+			derr  = c.FromError(status.FromProto(pst).Err())
+			v, ok = errors.LookupMD(derr, "user_id")
+		)
+		require.True(t, ok)
+		assert.Equal(t, "abc", v)
+		assert.Len(t, errors.GetMD(derr), 1)
+	})
+
+	t.Run("status without details", func(t *testing.T) {
+		var serr = status.Error(codes.Internal, "some error")
+		assert.Equal(t, serr, c.FromError(serr))
+	})
+
+	t.Run("not a status", func(t *testing.T) {
+		var err = stderrors.New("some error")
+		assert.Equal(t, err, c.FromError(err))
+		assert.Nil(t, c.FromError(nil))
+	})
+}
+
+func TestToError(t *testing.T) {
+	t.Run("created by the errors package", func(t *testing.T) {
+		var serr = ToError(errors.New(notFoundTestCode(true)))
+		assert.Equal(t, codes.NotFound, status.Code(serr))
+
+		var derr = FromError(serr)
+		assert.True(t, errors.Is(derr, notFoundTestCode(true)))
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		var err = stderrors.New("some error")
+		assert.Equal(t, err, ToError(err))
+		assert.Nil(t, ToError(nil))
+	})
+}
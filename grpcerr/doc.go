@@ -0,0 +1,30 @@
+/*
+Package grpcerr converts the errors created by the go.fraixed.es/errors
+package to gRPC statuses and vice versa, so they can be transmitted between gRPC
+servers and clients.
+
+It's a separated module, so the go.fraixed.es/errors module doesn't depend on
+gRPC.
+
+An error is converted to a gRPC status as follows:
+
+	code: the gRPC code of the error Code (see CodeMap and Coder) or
+	      codes.Unknown.
+	message: the message of the error Code filled with the public metadata
+	         (see errors.PublicMessage).
+	details: an ErrorInfo whose reason is the string representation of the
+	         error Code and it has the error ID in its metadata, under the "id"
+	         key, and the message of the error Code, under the "message" key,
+	         when it's different from the status message, and a Struct with
+	         the Converter domain, in the "domain" field, and the error
+	         metadata which is marked as public (see errors.PublicMD), in the
+	         "metadata" field.
+
+The client interceptors convert the statuses back to errors, so the clients
+can identify them with the functions of the go.fraixed.es/errors package.
+
+The call stack, the wrapped errors and the metadata which isn't public are never
+added to the status, because they are only destined to the operations team and
+maintainers.
+*/
+package grpcerr
@@ -0,0 +1,19 @@
+module go.fraixed.es/errors/grpcerr
+
+go 1.25.0
+
+require (
+	github.com/stretchr/testify v1.2.2
+	go.fraixed.es/errors v0.0.0-20261018105732-49fb1fae7420
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
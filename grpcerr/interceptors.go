@@ -0,0 +1,73 @@
+package grpcerr
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a gRPC unary server interceptor which
+// converts the errors returned by the handlers with c (see Converter.ToError).
+func (c Converter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		var resp, err = handler(ctx, req)
+		return resp, c.ToError(err)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream server interceptor which
+// converts the errors returned by the handlers with c (see Converter.ToError).
+func (c Converter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		return c.ToError(handler(srv, ss))
+	}
+}
+
+// UnaryClientInterceptor returns a gRPC unary client interceptor which
+// converts the errors returned by the calls with c (see Converter.FromError).
+func (c Converter) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		return c.FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a gRPC stream client interceptor which
+// converts the errors returned when creating the streams and by their SendMsg
+// and RecvMsg methods with c (see Converter.FromError).
+func (c Converter) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		var cs, err = streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, c.FromError(err)
+		}
+
+		return clientStream{ClientStream: cs, c: c}, nil
+	}
+}
+
+// clientStream is a grpc.ClientStream which converts the errors returned by
+// its SendMsg and RecvMsg methods.
+type clientStream struct {
+	grpc.ClientStream
+	c Converter
+}
+
+// SendMsg satisfies the grpc.ClientStream interface.
+func (s clientStream) SendMsg(m interface{}) error {
+	return s.c.FromError(s.ClientStream.SendMsg(m))
+}
+
+// RecvMsg satisfies the grpc.ClientStream interface.
+func (s clientStream) RecvMsg(m interface{}) error {
+	return s.c.FromError(s.ClientStream.RecvMsg(m))
+}
@@ -0,0 +1,93 @@
+package grpcerr
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestInterceptors(t *testing.T) {
+	var (
+		c   = Converter{Domain: "test.example.com"}
+		lis = bufconn.Listen(1024 * 1024)
+		srv = grpc.NewServer(
+			grpc.UnaryInterceptor(c.UnaryServerInterceptor()),
+			grpc.StreamInterceptor(c.StreamServerInterceptor()),
+		)
+		hsrv = &healthServer{
+			err: errors.New(notFoundTestCode(true),
+				errors.PublicMD("service", "test"), errors.MD{K: "internal", V: "secret"},
+			),
+		}
+	)
+
+	grpc_health_v1.RegisterHealthServer(srv, hsrv)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	var conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(c.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(c.StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	var client = grpc_health_v1.NewHealthClient(conn)
+
+	t.Run("unary", func(t *testing.T) {
+		var _, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, notFoundTestCode(true)))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		var id, _ = errors.GetID(err)
+		var expid, _ = errors.GetID(hsrv.err)
+		assert.Equal(t, expid, id)
+
+		assert.Equal(t, []errors.MD{errors.PublicMD("service", "test")}, errors.GetMD(err))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		var stream, err = client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, notFoundTestCode(true)))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, []errors.MD{errors.PublicMD("service", "test")}, errors.GetMD(err))
+	})
+}
+
+// healthServer is a gRPC health server which always returns err.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	err error
+}
+
+func (s *healthServer) Check(
+	context.Context, *grpc_health_v1.HealthCheckRequest,
+) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, s.err
+}
+
+func (s *healthServer) Watch(
+	*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer,
+) error {
+	return s.err
+}
@@ -0,0 +1,64 @@
+package grpcerr
+
+import "google.golang.org/grpc/codes"
+
+// testCode is a silly example of a Code implementation with the only purpose of
+// testing this package.
+type testCode bool
+
+func (testCode) String() string {
+	return "TestCode"
+}
+
+func (testCode) Message() string {
+	return "an test code error has happened"
+}
+
+// notFoundTestCode is a silly example of a Code implementation with the only
+// purpose of testing the Coder interface.
+type notFoundTestCode bool
+
+func (notFoundTestCode) String() string {
+	return "NotFoundTestCode"
+}
+
+func (notFoundTestCode) Message() string {
+	return "the test resource doesn't exist"
+}
+
+func (notFoundTestCode) GRPCCode() codes.Code {
+	return codes.NotFound
+}
+
+// tmplTestCode is a silly example of a Code implementation with the only
+// purpose of testing the message templates.
+type tmplTestCode bool
+
+func (tmplTestCode) String() string {
+	return "TmplTestCode"
+}
+
+func (tmplTestCode) Message() string {
+	return "the test item doesn't exist"
+}
+
+func (tmplTestCode) MessageTemplate() string {
+	return "the test item {item_id} doesn't exist"
+}
+
+// okTestCode is a silly example of a Code implementation with the only purpose
+// of testing the Codes which satisfy the Coder interface without having any
+// gRPC code.
+type okTestCode bool
+
+func (okTestCode) String() string {
+	return "OKTestCode"
+}
+
+func (okTestCode) Message() string {
+	return "a test error without gRPC code has happened"
+}
+
+func (okTestCode) GRPCCode() codes.Code {
+	return codes.OK
+}
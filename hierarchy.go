@@ -0,0 +1,108 @@
+package errors
+
+import "strings"
+
+// Parenter is the interface that the Codes satisfy for declaring the Code
+// which they are under, for example, a "storage.NotFound" Code under a
+// "storage" Code.
+// Parent returns nil when the Code isn't under any other.
+type Parenter interface {
+	Parent() Code
+}
+
+// NamespaceSeparator separates the namespaces of the string representation of
+// the Codes (value returned by the String method), hence a Code whose string
+// representation is "storage.NotFound" is under the "storage" namespace.
+const NamespaceSeparator = "."
+
+// Namespace is a Code which identifies all the Codes under it, which are the
+// ones whose string representation is prefixed by the namespace followed by
+// NamespaceSeparator, so it's meant to be used with IsKindOf when there isn't a
+// registered Code for the namespace.
+type Namespace string
+
+// String satisfies the Code interface.
+func (n Namespace) String() string {
+	return string(n)
+}
+
+// Message satisfies the Code interface.
+func (n Namespace) Message() string {
+	return "error of the " + string(n) + " namespace"
+}
+
+// IsKindOf returns true if the first error value, created by one of the
+// constructors of this package, found in the err chain has a Code which is
+// parent or any of its descendants in the hierarchy of r, otherwise it returns
+// false. The err chain is traversed with the same rule than Is.
+// The ancestors of a Code are the ones obtained by repeatedly calling
+// Registry.Parent, so the hierarchy is the same than the one returned by
+// Registry.Tree. They are compared with parent with the same rule than Is,
+// except when any of them is a Namespace, which are compared only by their
+// string representation.
+func (r *Registry) IsKindOf(err error, parent Code) bool {
+	var found bool
+	outermost(err, func(derr derror) bool {
+		found = r.descendantOf(derr.c, parent)
+		return !found
+	})
+
+	return found
+}
+
+// descendantOf returns true if c is parent or any of its descendants (see
+// Registry.IsKindOf), otherwise false.
+func (r *Registry) descendantOf(c, parent Code) bool {
+	// The number of ancestors is limited to avoid infinite loops with Codes
+	// which are their own ancestors.
+	for i := 0; i < maxAncestors; i++ {
+		if sameKind(c, parent) {
+			return true
+		}
+
+		var p, ok = r.Parent(c)
+		if !ok {
+			return false
+		}
+
+		c = p
+	}
+
+	return false
+}
+
+// sameKind returns true if a and b are the same Code, comparing them only by
+// their string representation when any of them is a Namespace, otherwise
+// false.
+func sameKind(a, b Code) bool {
+	var _, an = a.(Namespace)
+	var _, bn = b.(Namespace)
+	if an || bn {
+		return a.String() == b.String()
+	}
+
+	return equalCodes(a, b)
+}
+
+// IsKindOf returns true if err has a Code which is parent or any of its
+// descendants in the hierarchy of the default registry. See
+// Registry.IsKindOf.
+func IsKindOf(err error, parent Code) bool {
+	return defaultRegistry.IsKindOf(err, parent)
+}
+
+// maxAncestors is the maximum number of ancestors of a Code considered when
+// traversing its Parent methods.
+const maxAncestors = 32
+
+// namespaceOf returns the namespace of the string representation s of a Code
+// and true; if s isn't under any namespace, false is returned and the
+// namespace can be ignored.
+func namespaceOf(s string) (string, bool) {
+	var i = strings.LastIndex(s, NamespaceSeparator)
+	if i <= 0 {
+		return "", false
+	}
+
+	return s[:i], true
+}
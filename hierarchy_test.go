@@ -0,0 +1,205 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsKindOf(t *testing.T) {
+	var (
+		db      = parentedTestCode{s: "db"}
+		conn    = parentedTestCode{s: "conn", parent: db}
+		timeout = parentedTestCode{s: "timeout", parent: conn}
+	)
+
+	var tcases = []struct {
+		desc   string
+		err    error
+		parent Code
+		exp    bool
+	}{
+		{
+			desc:   "same code",
+			err:    New(hierarchyTestCode("storage.NotFound")),
+			parent: hierarchyTestCode("storage.NotFound"),
+			exp:    true,
+		},
+		{
+			desc:   "namespace",
+			err:    New(hierarchyTestCode("storage.NotFound")),
+			parent: Namespace("storage"),
+			exp:    true,
+		},
+		{
+			desc:   "registered code of the namespace",
+			err:    New(hierarchyTestCode("storage.sql.Conflict")),
+			parent: hierarchyTestCode("storage"),
+			exp:    true,
+		},
+		{
+			desc:   "namespace sharing the prefix",
+			err:    New(hierarchyTestCode("storages.NotFound")),
+			parent: Namespace("storage"),
+			exp:    false,
+		},
+		{
+			desc:   "descendant namespace",
+			err:    New(hierarchyTestCode("storage.NotFound")),
+			parent: Namespace("storage.NotFound.sql"),
+			exp:    false,
+		},
+		{
+			desc:   "parent method",
+			err:    New(conn),
+			parent: db,
+			exp:    true,
+		},
+		{
+			desc:   "grandparent method",
+			err:    Wrap(fmt.Errorf("dialing: %w", New(timeout)), testCode(true)),
+			parent: db,
+			exp:    false,
+		},
+		{
+			desc:   "grandparent method wrapped by a non coded error",
+			err:    fmt.Errorf("dialing: %w", New(timeout)),
+			parent: db,
+			exp:    true,
+		},
+		{
+			desc:   "child",
+			err:    New(conn),
+			parent: timeout,
+			exp:    false,
+		},
+		{
+			desc:   "code which is its own parent",
+			err:    New(selfParentTestCode(true)),
+			parent: db,
+			exp:    false,
+		},
+		{
+			desc: "aggregate",
+			err: Join(
+				New(testCode(true)),
+				New(hierarchyTestCode("storage.NotFound")),
+			),
+			parent: Namespace("storage"),
+			exp:    true,
+		},
+		{
+			desc:   "code of the namespace",
+			err:    New(hierarchyTestCode("storage")),
+			parent: Namespace("storage"),
+			exp:    true,
+		},
+		{
+			desc:   "parent method has precedence over the namespace",
+			err:    New(parentedTestCode{s: "storage.Timeout", parent: db}),
+			parent: Namespace("storage"),
+			exp:    false,
+		},
+		{
+			desc:   "namespace of an ancestor obtained through the parent method",
+			err:    New(parentedTestCode{s: "Timeout", parent: hierarchyTestCode("db.conn")}),
+			parent: Namespace("db"),
+			exp:    true,
+		},
+		{
+			desc:   "nil error",
+			parent: Namespace("storage"),
+			exp:    false,
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.exp, IsKindOf(tc.err, tc.parent))
+		})
+	}
+}
+
+func TestRegistry_IsKindOf(t *testing.T) {
+	var (
+		r       = NewRegistry()
+		storage = hierarchyTestCode("storage")
+		db      = parentedTestCode{s: "db"}
+		timeout = parentedTestCode{s: "storage.Timeout", parent: db}
+	)
+	r.MustRegister(storage, hierarchyTestCode("storage.NotFound"), db, timeout)
+
+	assert.True(t, r.IsKindOf(New(hierarchyTestCode("storage.NotFound")), storage))
+	assert.True(t, r.IsKindOf(New(hierarchyTestCode("storage.sql.Conflict")), storage))
+	assert.True(t, r.IsKindOf(New(storage), Namespace("storage")))
+	assert.False(t, r.IsKindOf(New(timeout), storage))
+
+	// The hierarchy is the same than the one of the tree.
+	var check func(ns []Node, ancestors []Code)
+	check = func(ns []Node, ancestors []Code) {
+		for _, n := range ns {
+			for _, a := range ancestors {
+				assert.True(t, r.IsKindOf(New(n.Code), a), "%s under %s", n.Code, a)
+			}
+
+			check(n.Children, append(ancestors[:len(ancestors):len(ancestors)], n.Code))
+		}
+	}
+	check(r.Tree(), nil)
+}
+
+func TestNamespace(t *testing.T) {
+	var ns = Namespace("storage")
+	assert.Equal(t, "storage", ns.String())
+	assert.NoError(t, ValidateMessage(ns.Message()))
+	assert.True(t, Is(New(ns), Namespace("storage")))
+}
+
+// hierarchyTestCode is a silly example of a Code implementation with the only
+// purpose of testing the hierarchy of the Codes; its value is its string
+// representation.
+type hierarchyTestCode string
+
+func (c hierarchyTestCode) String() string {
+	return string(c)
+}
+
+func (hierarchyTestCode) Message() string {
+	return "a test error of a hierarchy has happened"
+}
+
+// parentedTestCode is a silly example of a Code implementation with the only
+// purpose of testing the Codes which satisfy the Parenter interface.
+type parentedTestCode struct {
+	s      string
+	parent Code
+}
+
+func (c parentedTestCode) String() string {
+	return c.s
+}
+
+func (parentedTestCode) Message() string {
+	return "a test error with a parent has happened"
+}
+
+func (c parentedTestCode) Parent() Code {
+	return c.parent
+}
+
+// selfParentTestCode is a silly example of a Code implementation with the only
+// purpose of testing the Codes which are their own parent.
+type selfParentTestCode bool
+
+func (selfParentTestCode) String() string {
+	return "SelfParentTestCode"
+}
+
+func (selfParentTestCode) Message() string {
+	return "a test error which is its own parent has happened"
+}
+
+func (c selfParentTestCode) Parent() Code {
+	return c
+}
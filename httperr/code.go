@@ -0,0 +1,36 @@
+package httperr
+
+import "go.fraixed.es/errors"
+
+// ErrCode is the type of the Codes of the errors returned by this package.
+type ErrCode uint8
+
+const (
+	// ErrCodeMalformedProblem identifies the errors returned when a problem
+	// details document isn't valid or cannot be converted to an error.
+	ErrCodeMalformedProblem ErrCode = iota + 1
+)
+
+func init() {
+	errors.MustRegister(ErrCodeMalformedProblem)
+}
+
+// String satisfies the errors.Code interface.
+func (c ErrCode) String() string {
+	switch c {
+	case ErrCodeMalformedProblem:
+		return "httperr.MalformedProblem"
+	default:
+		return "httperr.UnknownCode"
+	}
+}
+
+// Message satisfies the errors.Code interface.
+func (c ErrCode) Message() string {
+	switch c {
+	case ErrCodeMalformedProblem:
+		return "the problem details document isn't valid"
+	default:
+		return "unknown error code of the httperr package"
+	}
+}
@@ -0,0 +1,170 @@
+package httperr
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.fraixed.es/errors"
+)
+
+// blankType is the problem type used when the problem doesn't have any
+// additional semantics beyond the HTTP status code.
+const blankType = "about:blank"
+
+// Codec converts errors to problem details documents and vice versa.
+// The zero value is ready to use.
+type Codec struct {
+	// TypeBaseURI is prefixed to the string representation of the Codes for
+	// building the problem types, and it's removed from the problem types for
+	// obtaining the string representation of the Codes.
+	TypeBaseURI string
+	// Registry is used for obtaining the HTTP status codes of the errors and
+	// the Codes of the parsed problem documents. When it's nil, the default
+	// registry is used.
+	Registry *errors.Registry
+}
+
+// Problem returns the problem details document of err.
+// The title is the message of the Code of err, the detail is its message
+// template filled with the public metadata (see errors.PublicCodeMessage), when
+// it's different from the title, and the extension members are the public
+// metadata. Only the metadata of the first error value, created by one of the
+// constructors of the go.fraixed.es/errors package, found in the err chain is
+// considered (see errors.GetMD), so the wrapped errors are never exposed.
+func (c Codec) Problem(err error) Problem {
+	var (
+		code, ok = errors.GetCode(err)
+		status   = c.registry().HTTPStatus(err)
+	)
+
+	if !ok {
+		return Problem{
+			Type:   blankType,
+			Title:  http.StatusText(status),
+			Status: status,
+		}
+	}
+
+	var (
+		id, _ = errors.GetID(err)
+		p     = Problem{
+			Type:     c.TypeBaseURI + code.String(),
+			Title:    code.Message(),
+			Status:   status,
+			Instance: id.String(),
+		}
+	)
+
+	var mds = errors.GetMD(err)
+
+	// The detail is only set when it's specific to this occurrence.
+	if d := errors.PublicCodeMessage(code, mds...); d != p.Title {
+		p.Detail = d
+	}
+
+	for _, md := range errors.PublicMDs(mds) {
+		if p.Extensions == nil {
+			p.Extensions = map[string]interface{}{}
+		}
+
+		p.Extensions[md.K] = md.V
+	}
+
+	return p
+}
+
+// Write writes the problem details document of err to w, setting the
+// Content-Type header and the HTTP status code.
+func (c Codec) Write(w http.ResponseWriter, err error) error {
+	var p = c.Problem(err)
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+
+	return json.NewEncoder(w).Encode(p)
+}
+
+// Error returns the error represented by p, which is reconstructed with the
+// Code, ID and public metadata obtained from p.
+// When p type is "about:blank", or it's empty, the returned error is a
+// standard error whose message is p title.
+//
+// The second returned value is an error with the ErrCodeMalformedProblem Code
+// when p cannot be converted to an error, in such case, the first returned
+// value is nil.
+func (c Codec) Error(p Problem) (error, error) {
+	if p.Type == "" || p.Type == blankType {
+		var msg = p.Title
+		if msg == "" {
+			msg = http.StatusText(p.Status)
+		}
+
+		return stderrors.New(msg), nil
+	}
+
+	if !strings.HasPrefix(p.Type, c.TypeBaseURI) {
+		return nil, errors.New(ErrCodeMalformedProblem,
+			errors.MD{K: "type", V: p.Type}, errors.MD{K: "type_base_uri", V: c.TypeBaseURI},
+		)
+	}
+
+	if p.Instance == "" {
+		return nil, errors.New(ErrCodeMalformedProblem, errors.MD{K: "type", V: p.Type})
+	}
+
+	var keys = make([]string, 0, len(p.Extensions))
+	for k := range p.Extensions {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var mds = make([]errors.MD, len(keys))
+	for i, k := range keys {
+		mds[i] = errors.PublicMD(k, p.Extensions[k])
+	}
+
+	var code = c.registry().Resolve(strings.TrimPrefix(p.Type, c.TypeBaseURI), p.Title)
+	return errors.Restore(code, errors.ID(p.Instance), nil, mds...), nil
+}
+
+// Decode reads a problem details document from r and returns the error which
+// it represents. See Codec.Error.
+func (c Codec) Decode(r io.Reader) (error, error) {
+	var p Problem
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, errors.Wrap(err, ErrCodeMalformedProblem)
+	}
+
+	return c.Error(p)
+}
+
+func (c Codec) registry() *errors.Registry {
+	if c.Registry == nil {
+		return errors.DefaultRegistry()
+	}
+
+	return c.Registry
+}
+
+// NewProblem returns the problem details document of err using the zero value
+// Codec. See Codec.Problem.
+func NewProblem(err error) Problem {
+	return Codec{}.Problem(err)
+}
+
+// Write writes the problem details document of err to w using the zero value
+// Codec. See Codec.Write.
+func Write(w http.ResponseWriter, err error) error {
+	return Codec{}.Write(w, err)
+}
+
+// Decode reads a problem details document from r and returns the error which
+// it represents using the zero value Codec. See Codec.Decode.
+func Decode(r io.Reader) (error, error) {
+	return Codec{}.Decode(r)
+}
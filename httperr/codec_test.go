@@ -0,0 +1,204 @@
+package httperr
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+)
+
+func TestCodec_Problem(t *testing.T) {
+	var (
+		r = errors.NewRegistry()
+		c = Codec{TypeBaseURI: "https://example.com/problems/", Registry: r}
+	)
+	r.SetHTTPStatus(notFoundTestCode(true), http.StatusNotFound)
+
+	t.Run("created by the errors package", func(t *testing.T) {
+		var (
+			err = errors.Wrap(
+				errors.New(testCode(true), errors.PublicMD("inner", 1), errors.MD{K: "secret", V: "s"}),
+				testCode(true),
+				errors.PublicMD("user_id", "abc"), errors.MD{K: "query", V: "SELECT 1"},
+				errors.SecretMD("email", "ivan@example.com"),
+			)
+			id, _ = errors.GetID(err)
+		)
+
+		assert.Equal(t, Problem{
+			Type:     "https://example.com/problems/TestCode",
+			Title:    "an test code error has happened",
+			Status:   http.StatusInternalServerError,
+			Instance: id.String(),
+			Extensions: map[string]interface{}{
+				"user_id": "abc",
+			},
+		}, c.Problem(err))
+	})
+
+	t.Run("code with HTTP status", func(t *testing.T) {
+		var (
+			err   = errors.Wrap(errors.New(notFoundTestCode(true)), testCode(true))
+			id, _ = errors.GetID(err)
+		)
+
+		assert.Equal(t, Problem{
+			Type:     "https://example.com/problems/TestCode",
+			Title:    "an test code error has happened",
+			Status:   http.StatusNotFound,
+			Instance: id.String(),
+		}, c.Problem(err))
+	})
+
+	t.Run("message template", func(t *testing.T) {
+		var (
+			err   = errors.New(tmplTestCode(true), errors.PublicMD("item_id", 5))
+			id, _ = errors.GetID(err)
+		)
+
+		assert.Equal(t, Problem{
+			Type:       "https://example.com/problems/TmplTestCode",
+			Title:      "the test item doesn't exist",
+			Status:     http.StatusInternalServerError,
+			Detail:     "the test item 5 doesn't exist",
+			Instance:   id.String(),
+			Extensions: map[string]interface{}{"item_id": 5},
+		}, c.Problem(err))
+
+		// The internal metadata isn't used for filling the template.
+		err = errors.New(tmplTestCode(true), errors.MD{K: "item_id", V: 5})
+		assert.Empty(t, c.Problem(err).Detail)
+
+		// Neither the metadata of the wrapped errors.
+		err = errors.Wrap(errors.New(testCode(true), errors.PublicMD("item_id", 5)), tmplTestCode(true))
+		assert.Empty(t, c.Problem(err).Detail)
+		assert.Empty(t, c.Problem(err).Extensions)
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		var err = stderrors.New("some error with sensitive information")
+		assert.Equal(t, Problem{
+			Type:   "about:blank",
+			Title:  "Internal Server Error",
+			Status: http.StatusInternalServerError,
+		}, c.Problem(err))
+	})
+}
+
+func TestCodec_Write(t *testing.T) {
+	var (
+		c   = Codec{TypeBaseURI: "https://example.com/problems/"}
+		err = errors.Wrap(stderrors.New("ext error"), testCode(true), errors.PublicMD("user_id", "abc"))
+		rec = httptest.NewRecorder()
+	)
+
+	require.NoError(t, c.Write(rec, err))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+
+	var id, _ = errors.GetID(err)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"type": "https://example.com/problems/TestCode",
+		"title": "an test code error has happened",
+		"status": 500,
+		"instance": %q,
+		"user_id": "abc"
+	}`, id), rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "ext error")
+	assert.NotContains(t, rec.Body.String(), "stack")
+}
+
+func TestCodec_Decode(t *testing.T) {
+	var (
+		r = errors.NewRegistry()
+		c = Codec{TypeBaseURI: "https://example.com/problems/", Registry: r}
+	)
+	r.MustRegister(testCode(true))
+
+	t.Run("round trip", func(t *testing.T) {
+		var (
+			err = errors.New(testCode(true), errors.PublicMD("user_id", "abc"), errors.MD{K: "query", V: "q"})
+			rec = httptest.NewRecorder()
+		)
+		require.NoError(t, c.Write(rec, err))
+
+		var derr, perr = c.Decode(rec.Body)
+		require.NoError(t, perr)
+
+		var code, _ = errors.GetCode(derr)
+		assert.Equal(t, testCode(true), code)
+
+		var id, _ = errors.GetID(derr)
+		var expid, _ = errors.GetID(err)
+		assert.Equal(t, expid, id)
+		assert.Equal(t, []errors.MD{errors.PublicMD("user_id", "abc")}, errors.GetMD(derr))
+	})
+
+	t.Run("code isn't registered", func(t *testing.T) {
+		var derr, perr = c.Decode(strings.NewReader(`{
+			"type": "https://example.com/problems/Unknown",
+			"title": "unknown code",
+			"status": 400,
+			"instance": "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b"
+		}`))
+		require.NoError(t, perr)
+
+		var code, _ = errors.GetCode(derr)
+		assert.Equal(t, "Unknown", code.String())
+		assert.Equal(t, "unknown code", code.Message())
+	})
+
+	t.Run("blank type", func(t *testing.T) {
+		var derr, perr = c.Decode(strings.NewReader(`{"type": "about:blank", "status": 404}`))
+		require.NoError(t, perr)
+		assert.EqualError(t, derr, "Not Found")
+
+		var _, ok = errors.GetCode(derr)
+		assert.False(t, ok)
+	})
+
+	t.Run("malformed problem", func(t *testing.T) {
+		var tcases = []struct {
+			desc string
+			data string
+		}{
+			{desc: "invalid JSON", data: `{"type": `},
+			{desc: "type without base URI", data: `{"type": "TestCode", "instance": "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b"}`},
+			{desc: "without instance", data: `{"type": "https://example.com/problems/TestCode"}`},
+		}
+
+		for i := range tcases {
+			var tc = tcases[i]
+			t.Run(tc.desc, func(t *testing.T) {
+				var derr, perr = c.Decode(strings.NewReader(tc.data))
+				assert.Nil(t, derr)
+				assert.True(t, errors.Is(perr, ErrCodeMalformedProblem))
+			})
+		}
+	})
+}
+
+func TestPackageFunctions(t *testing.T) {
+	var (
+		err = errors.New(testCode(true))
+		p   = NewProblem(err)
+	)
+	assert.Equal(t, "TestCode", p.Type)
+
+	var rec = httptest.NewRecorder()
+	require.NoError(t, Write(rec, err))
+
+	var b, _ = json.Marshal(p)
+	assert.JSONEq(t, string(b), rec.Body.String())
+
+	var derr, perr = Decode(rec.Body)
+	require.NoError(t, perr)
+	assert.True(t, errors.Is(derr, testCode(true)))
+}
@@ -0,0 +1,23 @@
+/*
+Package httperr renders the errors created by the go.fraixed.es/errors
+package as problem details documents (RFC 7807), whose media type is
+"application/problem+json", and parses them back into errors.
+
+A problem document is built from an error as follows:
+
+	type: the string representation of the Code, prefixed by the configured
+	      type base URI.
+	title: the message of the Code.
+	status: the HTTP status code (see errors.HTTPStatus).
+	instance: the ID of the error.
+
+Additionally, the metadata of the error which is marked as public (see
+errors.PublicMD) is added as extension members. The call stack, the wrapped
+errors and the metadata which isn't public are never added, because they are
+only destined to the operations team and maintainers.
+
+Errors which aren't created by the go.fraixed.es/errors package are rendered as
+a problem document whose type is "about:blank", with the 500 HTTP status code,
+and doesn't contain any information of the error.
+*/
+package httperr
@@ -0,0 +1,103 @@
+package httperr
+
+import (
+	"encoding/json"
+)
+
+// ContentType is the media type of the problem details documents.
+const ContentType = "application/problem+json"
+
+// Problem is a problem details document (RFC 7807).
+// Extensions contains the extension members, which are marshalled as members
+// of the JSON object at the same level than the rest of the fields.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// problemMembers are the members of a problem details document defined by RFC
+// 7807.
+type problemMembers struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// reservedMembers are the names of the members defined by RFC 7807, hence they
+// cannot be used by extension members.
+var reservedMembers = map[string]struct{}{
+	"type":     {},
+	"title":    {},
+	"status":   {},
+	"detail":   {},
+	"instance": {},
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// Extension members whose names are the same than the members defined by RFC
+// 7807 are ignored.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	var m = make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		if _, ok := reservedMembers[k]; ok {
+			continue
+		}
+
+		m[k] = v
+	}
+
+	var b, err = json.Marshal(problemMembers{
+		Type:     p.Type,
+		Title:    p.Title,
+		Status:   p.Status,
+		Detail:   p.Detail,
+		Instance: p.Instance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var pm problemMembers
+	if err := json.Unmarshal(data, &pm); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	for k := range reservedMembers {
+		delete(m, k)
+	}
+
+	if len(m) == 0 {
+		m = nil
+	}
+
+	*p = Problem{
+		Type:       pm.Type,
+		Title:      pm.Title,
+		Status:     pm.Status,
+		Detail:     pm.Detail,
+		Instance:   pm.Instance,
+		Extensions: m,
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package httperr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	var p = Problem{
+		Type:     "https://example.com/problems/TestCode",
+		Title:    "an test code error has happened",
+		Status:   500,
+		Instance: "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b",
+		Extensions: map[string]interface{}{
+			"user_id": 10,
+			"title":   "ignored",
+		},
+	}
+
+	var b, err = json.Marshal(p)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "https://example.com/problems/TestCode",
+		"title": "an test code error has happened",
+		"status": 500,
+		"instance": "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b",
+		"user_id": 10
+	}`, string(b))
+}
+
+func TestProblem_UnmarshalJSON(t *testing.T) {
+	t.Run("with extensions", func(t *testing.T) {
+		var p Problem
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "TestCode",
+			"title": "an test code error has happened",
+			"status": 400,
+			"detail": "some detail",
+			"instance": "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b",
+			"user_id": "abc"
+		}`), &p))
+
+		assert.Equal(t, Problem{
+			Type:       "TestCode",
+			Title:      "an test code error has happened",
+			Status:     400,
+			Detail:     "some detail",
+			Instance:   "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b",
+			Extensions: map[string]interface{}{"user_id": "abc"},
+		}, p)
+	})
+
+	t.Run("without extensions", func(t *testing.T) {
+		var p Problem
+		require.NoError(t, json.Unmarshal([]byte(`{"type": "about:blank", "status": 500}`), &p))
+		assert.Equal(t, Problem{Type: "about:blank", Status: 500}, p)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		var p Problem
+		assert.Error(t, json.Unmarshal([]byte(`{"status": "500"}`), &p))
+	})
+}
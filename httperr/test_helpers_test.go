@@ -0,0 +1,41 @@
+package httperr
+
+// testCode is a silly example of a Code implementation with the only purpose of
+// testing this package.
+type testCode bool
+
+func (testCode) String() string {
+	return "TestCode"
+}
+
+func (testCode) Message() string {
+	return "an test code error has happened"
+}
+
+// notFoundTestCode is a silly example of a Code implementation with the only
+// purpose of testing the HTTP status codes of the problems.
+type notFoundTestCode bool
+
+func (notFoundTestCode) String() string {
+	return "NotFoundTestCode"
+}
+
+func (notFoundTestCode) Message() string {
+	return "the test resource doesn't exist"
+}
+
+// tmplTestCode is a silly example of a Code implementation with the only
+// purpose of testing the message templates.
+type tmplTestCode bool
+
+func (tmplTestCode) String() string {
+	return "TmplTestCode"
+}
+
+func (tmplTestCode) Message() string {
+	return "the test item doesn't exist"
+}
+
+func (tmplTestCode) MessageTemplate() string {
+	return "the test item {item_id} doesn't exist"
+}
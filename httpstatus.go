@@ -0,0 +1,66 @@
+package errors
+
+import "net/http"
+
+// HTTPStatuser is the interface that the Codes can optionally satisfy for
+// indicating the HTTP status code which corresponds to the errors identified
+// by them.
+// HTTPStatus returns 0 when the Code doesn't have any, so it's considered as if
+// it didn't satisfy this interface.
+type HTTPStatuser interface {
+	HTTPStatus() int
+}
+
+// SetHTTPStatus sets status as the HTTP status code of the errors identified by
+// c, or by any other Code with the same string representation, overriding the
+// one returned by c if it satisfies the HTTPStatuser interface.
+// It allows to map Codes defined by third party packages and it doesn't
+// require c to be registered.
+func (r *Registry) SetHTTPStatus(c Code, status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses[c.String()] = status
+}
+
+// HTTPStatus returns the HTTP status code of err, which is the one of the
+// first error value, created by one of the constructors of this package, found
+// in the err chain whose Code has an HTTP status code set in r (see
+// SetHTTPStatus) or satisfies the HTTPStatuser interface, with an HTTP status
+// code other than 0; the one set in r has precedence.
+// When there isn't any, it returns http.StatusInternalServerError.
+func (r *Registry) HTTPStatus(err error) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var status = http.StatusInternalServerError
+	walk(err, func(derr derror) bool {
+		if s, ok := r.statuses[derr.c.String()]; ok {
+			status = s
+			return false
+		}
+
+		if hs, ok := derr.c.(HTTPStatuser); ok {
+			if s := hs.HTTPStatus(); s != 0 {
+				status = s
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return status
+}
+
+// SetHTTPStatus sets status as the HTTP status code of the errors identified by
+// c in the default registry. See Registry.SetHTTPStatus.
+func SetHTTPStatus(c Code, status int) {
+	defaultRegistry.SetHTTPStatus(c, status)
+}
+
+// HTTPStatus returns the HTTP status code of err using the default registry.
+// See Registry.HTTPStatus.
+func HTTPStatus(err error) int {
+	return defaultRegistry.HTTPStatus(err)
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_HTTPStatus(t *testing.T) {
+	var r = NewRegistry()
+	r.SetHTTPStatus(differentTestCode(true), http.StatusConflict)
+
+	var tcases = []struct {
+		desc      string
+		err       error
+		expstatus int
+	}{
+		{
+			desc:      "code satisfies HTTPStatuser",
+			err:       New(httpTestCode(http.StatusNotFound)),
+			expstatus: http.StatusNotFound,
+		},
+		{
+			desc:      "code with status set in the registry",
+			err:       New(differentTestCode(true)),
+			expstatus: http.StatusConflict,
+		},
+		{
+			desc:      "code without status",
+			err:       New(testCode(true)),
+			expstatus: http.StatusInternalServerError,
+		},
+		{
+			desc: "code with status in the chain",
+			err: Wrap(
+				fmt.Errorf("some context: %w", New(httpTestCode(http.StatusBadRequest))),
+				testCode(true),
+			),
+			expstatus: http.StatusBadRequest,
+		},
+		{
+			desc:      "first code with status in the chain",
+			err:       Wrap(New(differentTestCode(true)), httpTestCode(http.StatusBadRequest)),
+			expstatus: http.StatusBadRequest,
+		},
+		{
+			desc:      "code with zero status",
+			err:       New(httpTestCode(0)),
+			expstatus: http.StatusInternalServerError,
+		},
+		{
+			desc:      "code with zero status wrapping code with status",
+			err:       Wrap(New(httpTestCode(http.StatusServiceUnavailable)), httpTestCode(0)),
+			expstatus: http.StatusServiceUnavailable,
+		},
+		{
+			desc:      "created by other package",
+			err:       errors.New("some error"),
+			expstatus: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expstatus, r.HTTPStatus(tc.err))
+		})
+	}
+
+	t.Run("status set in the registry has precedence", func(t *testing.T) {
+		var r = NewRegistry()
+		r.SetHTTPStatus(httpTestCode(http.StatusNotFound), http.StatusGone)
+		assert.Equal(t, http.StatusGone, r.HTTPStatus(New(httpTestCode(http.StatusNotFound))))
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatus(New(otherTestCode(true))))
+
+	SetHTTPStatus(otherTestCode(true), http.StatusTeapot)
+	defer func() {
+		defaultRegistry.mu.Lock()
+		delete(defaultRegistry.statuses, otherTestCode(true).String())
+		defaultRegistry.mu.Unlock()
+	}()
+
+	assert.Equal(t, http.StatusTeapot, HTTPStatus(New(otherTestCode(true))))
+}
+
+// httpTestCode is a silly example of a Code implementation with the only
+// purpose of testing the HTTPStatuser interface.
+type httpTestCode int
+
+func (httpTestCode) String() string {
+	return "HTTPTestCode"
+}
+
+func (httpTestCode) Message() string {
+	return "an HTTP test code error has happened"
+}
+
+func (c httpTestCode) HTTPStatus() int {
+	return int(c)
+}
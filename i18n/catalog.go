@@ -0,0 +1,215 @@
+package i18n
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"go.fraixed.es/errors"
+	"golang.org/x/text/language"
+)
+
+// Catalog holds the translated messages of Codes by language.
+// A Catalog is safe for concurrent use.
+type Catalog struct {
+	// Registry is used for obtaining the Codes of the loaded messages, so the
+	// ones of the Codes which satisfy the errors.MessageTemplater interface
+	// are validated as message templates. When it's nil, the default registry
+	// is used.
+	Registry *errors.Registry
+
+	mu   sync.RWMutex
+	msgs map[language.Tag]map[string]string
+}
+
+// NewCatalog creates an empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{msgs: map[language.Tag]map[string]string{}}
+}
+
+// Set sets msg as the message of the Code whose string representation is code
+// for the language tag, replacing the existing one if any.
+func (c *Catalog) Set(tag language.Tag, code string, msg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var msgs, ok = c.msgs[tag]
+	if !ok {
+		msgs = map[string]string{}
+		c.msgs[tag] = msgs
+	}
+
+	msgs[code] = msg
+}
+
+// Lookup returns the message of the Code whose string representation is code
+// for the language tag, without falling back to any other language. It
+// returns false if there isn't any.
+func (c *Catalog) Lookup(tag language.Tag, code string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var msg, ok = c.msgs[tag][code]
+	return msg, ok
+}
+
+// Languages returns the languages which have at least one message.
+func (c *Catalog) Languages() []language.Tag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var tags = make([]language.Tag, 0, len(c.msgs))
+	for t := range c.msgs {
+		tags = append(tags, t)
+	}
+
+	return tags
+}
+
+// LoadFile loads the messages of the file for the language tag. The format of
+// the file is determined by its extension: ".json", ".toml" or ".po".
+//
+// It returns an error with the ErrCodeUnsupportedFormat Code if the extension
+// isn't one of the above and any error returned by LoadJSON, LoadTOML or
+// LoadPO.
+func (c *Catalog) LoadFile(tag language.Tag, path string) error {
+	var load func(language.Tag, io.Reader) error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		load = c.LoadJSON
+	case ".toml":
+		load = c.LoadTOML
+	case ".po":
+		load = c.LoadPO
+	default:
+		return errors.New(ErrCodeUnsupportedFormat, errors.MD{K: "path", V: path})
+	}
+
+	var f, err = os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close() // nolint: errcheck
+
+	if err := load(tag, f); err != nil {
+		return errors.Wrap(err, ErrCodeMalformedCatalog, errors.MD{K: "path", V: path})
+	}
+
+	return nil
+}
+
+// LoadJSON loads the messages of the JSON document read from r for the
+// language tag.
+//
+// It returns an error with the ErrCodeMalformedCatalog Code if the document
+// isn't well formed or any of its messages, whose Code satisfies the
+// errors.MessageTemplater interface, isn't a well formed template; in such
+// case, none of its messages are loaded.
+func (c *Catalog) LoadJSON(tag language.Tag, r io.Reader) error {
+	var doc map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return errors.Wrap(err, ErrCodeMalformedCatalog)
+	}
+
+	return c.load(tag, doc)
+}
+
+// LoadTOML loads the messages of the TOML document read from r for the
+// language tag.
+//
+// It returns the same errors than LoadJSON.
+func (c *Catalog) LoadTOML(tag language.Tag, r io.Reader) error {
+	var doc map[string]interface{}
+	if _, err := toml.NewDecoder(r).Decode(&doc); err != nil {
+		return errors.Wrap(err, ErrCodeMalformedCatalog)
+	}
+
+	return c.load(tag, doc)
+}
+
+// LoadPO loads the messages of the gettext .po file read from r for the
+// language tag.
+//
+// It returns the same errors than LoadJSON.
+func (c *Catalog) LoadPO(tag language.Tag, r io.Reader) error {
+	var msgs, err = parsePO(r)
+	if err != nil {
+		return err
+	}
+
+	return c.set(tag, msgs)
+}
+
+// load flattens doc and sets its messages for the language tag.
+func (c *Catalog) load(tag language.Tag, doc map[string]interface{}) error {
+	var msgs = map[string]string{}
+	if err := flatten("", doc, msgs); err != nil {
+		return err
+	}
+
+	return c.set(tag, msgs)
+}
+
+// set validates that the messages of msgs, whose Codes satisfy the
+// errors.MessageTemplater interface, are well formed message templates (see
+// errors.ValidateMessage) and sets them for the language tag; if any isn't,
+// none is set and an error with the ErrCodeMalformedCatalog Code is returned.
+// The messages of the other Codes, registered or not, are never considered
+// templates.
+func (c *Catalog) set(tag language.Tag, msgs map[string]string) error {
+	for code, msg := range msgs {
+		var ec, ok = c.registry().Lookup(code)
+		if !ok {
+			continue
+		}
+
+		if _, ok := ec.(errors.MessageTemplater); !ok {
+			continue
+		}
+
+		if err := errors.ValidateMessage(msg); err != nil {
+			return errors.Wrap(err, ErrCodeMalformedCatalog, errors.MD{K: "key", V: code})
+		}
+	}
+
+	for code, msg := range msgs {
+		c.Set(tag, code, msg)
+	}
+
+	return nil
+}
+
+func (c *Catalog) registry() *errors.Registry {
+	if c.Registry == nil {
+		return errors.DefaultRegistry()
+	}
+
+	return c.Registry
+}
+
+// flatten adds the string members of doc to msgs, prefixing their names with
+// prefix, and recurses into its objects.
+func flatten(prefix string, doc map[string]interface{}, msgs map[string]string) error {
+	for k, v := range doc {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		switch v := v.(type) {
+		case string:
+			msgs[k] = v
+		case map[string]interface{}:
+			if err := flatten(k, v, msgs); err != nil {
+				return err
+			}
+		default:
+			return errors.New(ErrCodeMalformedCatalog, errors.MD{K: "key", V: k})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+	"golang.org/x/text/language"
+)
+
+func TestCatalog_Set(t *testing.T) {
+	var c = NewCatalog()
+
+	var _, ok = c.Lookup(language.Spanish, "TestCode")
+	assert.False(t, ok)
+
+	c.Set(language.Spanish, "TestCode", "error de prueba")
+	msg, ok := c.Lookup(language.Spanish, "TestCode")
+	assert.True(t, ok)
+	assert.Equal(t, "error de prueba", msg)
+
+	c.Set(language.Spanish, "TestCode", "otro error de prueba")
+	msg, _ = c.Lookup(language.Spanish, "TestCode")
+	assert.Equal(t, "otro error de prueba", msg)
+
+	_, ok = c.Lookup(language.Catalan, "TestCode")
+	assert.False(t, ok)
+
+	assert.Equal(t, []language.Tag{language.Spanish}, c.Languages())
+}
+
+func TestCatalog_LoadFile(t *testing.T) {
+	for _, path := range []string{"testdata/es.json", "testdata/es.toml", "testdata/es.po"} {
+		var p = path
+		t.Run(p, func(t *testing.T) {
+			var c = NewCatalog()
+			require.NoError(t, c.LoadFile(language.Spanish, p))
+
+			var msg, ok = c.Lookup(language.Spanish, "TestCode")
+			assert.True(t, ok)
+			assert.Equal(t, "ha ocurrido un error de prueba", msg)
+
+			msg, ok = c.Lookup(language.Spanish, "storage.NotFound")
+			assert.True(t, ok)
+			assert.Equal(t, "no se ha encontrado el elemento", msg)
+
+			_, ok = c.Lookup(language.Spanish, "storage.Conflict")
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("unsupported format", func(t *testing.T) {
+		var err = NewCatalog().LoadFile(language.Spanish, "testdata/es.yaml")
+		assert.True(t, errors.Is(err, ErrCodeUnsupportedFormat))
+	})
+
+	t.Run("not existing file", func(t *testing.T) {
+		var err = NewCatalog().LoadFile(language.Spanish, "testdata/none.json")
+		assert.Error(t, err)
+	})
+}
+
+func TestCatalog_LoadJSON(t *testing.T) {
+	var tcases = []struct {
+		desc string
+		doc  string
+	}{
+		{desc: "invalid JSON", doc: `{"TestCode":`},
+		{desc: "not an object", doc: `["TestCode"]`},
+		{desc: "not a string message", doc: `{"TestCode": 1}`},
+		{desc: "not a string nested message", doc: `{"storage": {"NotFound": true}}`},
+		{desc: "malformed template", doc: `{"TestCode": "error", "TmplTestCode": "error {id"}`},
+	}
+
+	var r = errors.NewRegistry()
+	r.MustRegister(testCode(true), tmplTestCode(true))
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var c = NewCatalog()
+			c.Registry = r
+
+			var err = c.LoadJSON(language.Spanish, strings.NewReader(tc.doc))
+			assert.True(t, errors.Is(err, ErrCodeMalformedCatalog))
+			assert.Empty(t, c.Languages())
+		})
+	}
+
+	t.Run("braces of codes without template", func(t *testing.T) {
+		var c = NewCatalog()
+		c.Registry = r
+
+		require.NoError(t, c.LoadJSON(language.Spanish, strings.NewReader(
+			`{"TestCode": "error {id", "storage.NotFound": "{no} encontrado"}`,
+		)))
+
+		var msg, _ = c.Lookup(language.Spanish, "TestCode")
+		assert.Equal(t, "error {id", msg)
+	})
+}
+
+func TestCatalog_LoadTOML(t *testing.T) {
+	var tcases = []struct {
+		desc string
+		doc  string
+	}{
+		{desc: "invalid TOML", doc: `TestCode = `},
+		{desc: "not a string message", doc: `TestCode = 1`},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var (
+				c   = NewCatalog()
+				err = c.LoadTOML(language.Spanish, strings.NewReader(tc.doc))
+			)
+			assert.True(t, errors.Is(err, ErrCodeMalformedCatalog))
+			assert.Empty(t, c.Languages())
+		})
+	}
+
+	t.Run("quoted keys", func(t *testing.T) {
+		var c = NewCatalog()
+		require.NoError(t, c.LoadTOML(language.Spanish, strings.NewReader(`"storage.NotFound" = "no encontrado"`)))
+
+		var msg, ok = c.Lookup(language.Spanish, "storage.NotFound")
+		assert.True(t, ok)
+		assert.Equal(t, "no encontrado", msg)
+	})
+}
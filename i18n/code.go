@@ -0,0 +1,43 @@
+package i18n
+
+import "go.fraixed.es/errors"
+
+// ErrCode is the type of the Codes of the errors returned by this package.
+type ErrCode uint8
+
+const (
+	// ErrCodeMalformedCatalog identifies the errors returned when loading a
+	// translations file which isn't well formed.
+	ErrCodeMalformedCatalog ErrCode = iota + 1
+	// ErrCodeUnsupportedFormat identifies the errors returned when loading a
+	// translations file whose format isn't supported.
+	ErrCodeUnsupportedFormat
+)
+
+func init() {
+	errors.MustRegister(ErrCodeMalformedCatalog, ErrCodeUnsupportedFormat)
+}
+
+// String satisfies the errors.Code interface.
+func (c ErrCode) String() string {
+	switch c {
+	case ErrCodeMalformedCatalog:
+		return "i18n.MalformedCatalog"
+	case ErrCodeUnsupportedFormat:
+		return "i18n.UnsupportedFormat"
+	default:
+		return "i18n.UnknownCode"
+	}
+}
+
+// Message satisfies the errors.Code interface.
+func (c ErrCode) Message() string {
+	switch c {
+	case ErrCodeMalformedCatalog:
+		return "the translations file is malformed"
+	case ErrCodeUnsupportedFormat:
+		return "the format of the translations file isn't supported"
+	default:
+		return "unknown error code of the i18n package"
+	}
+}
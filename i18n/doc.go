@@ -0,0 +1,39 @@
+/*
+Package i18n localizes the messages of the Codes of the errors created by the
+go.fraixed.es/errors package.
+
+The translations are kept in a Catalog, keyed by language and by the string
+representation of the Codes, and they can be loaded from JSON, TOML and gettext
+.po files.
+
+JSON files contain an object whose members are the Codes and their values the
+translated messages; nested objects are flattened joining their names with a
+dot, hence the following files are equivalent.
+
+	{"storage.NotFound": "no se ha encontrado el elemento"}
+
+	{"storage": {"NotFound": "no se ha encontrado el elemento"}}
+
+TOML files follow the same rules.
+
+	[storage]
+	NotFound = "no se ha encontrado el elemento"
+
+.po files contain an entry for each Code, the msgid is the Code and the msgstr
+the translated message; entries with an empty msgstr are ignored.
+
+	msgid "storage.NotFound"
+	msgstr "no se ha encontrado el elemento"
+
+A Localizer returns the message of an error for a language, falling back
+through its parent languages (e.g. "es-ES" falls back to "es") and, as a last
+resort, to the message of the Code.
+
+The translations of the Codes which satisfy the errors.MessageTemplater
+interface are message templates, filled with the public metadata of the
+errors, whereas the translations of the rest are returned as they are.
+
+It's a separated module, so the go.fraixed.es/errors module doesn't depend on
+the golang.org/x/text module and the TOML parser.
+*/
+package i18n
@@ -0,0 +1,15 @@
+module go.fraixed.es/errors/i18n
+
+go 1.25.0
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/stretchr/testify v1.2.2
+	go.fraixed.es/errors v0.0.0-20261018105732-49fb1fae7420
+	golang.org/x/text v0.40.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"go.fraixed.es/errors"
+	"golang.org/x/text/language"
+)
+
+// Localizer returns the localized messages of the errors.
+type Localizer struct {
+	// Catalog is the catalog where the messages are looked up.
+	Catalog *Catalog
+}
+
+// NewLocalizer creates a Localizer which looks up the messages in c.
+func NewLocalizer(c *Catalog) *Localizer {
+	return &Localizer{Catalog: c}
+}
+
+// Message returns the message of the Code of err (see errors.GetCode) for the
+// language tag. The messages of the Codes which don't satisfy the
+// errors.MessageTemplater interface are returned as they are.
+//
+// The messages of the Codes which satisfy it are templates whose placeholders
+// are filled with the public metadata of err as errors.PublicCodeMessage does,
+// so they can be exposed to the users; when they cannot be filled, it returns
+// the message of the Code. Only the metadata of the first error value, created
+// by one of the constructors of the go.fraixed.es/errors package, found in the
+// err chain is considered (see errors.GetMD), so the wrapped errors are never
+// exposed.
+//
+// When the catalog doesn't have the message for tag, it tries its parent
+// languages (e.g. "es-ES", "es") and, if none of them has it, it uses the
+// message template of the Code, or its message when it isn't a
+// MessageTemplater.
+//
+// It returns the message of err (i.e. err.Error()) if err doesn't have any
+// Code and an empty string if err is nil.
+func (l *Localizer) Message(err error, tag language.Tag) string {
+	if err == nil {
+		return ""
+	}
+
+	var c, ok = errors.GetCode(err)
+	if !ok {
+		return err.Error()
+	}
+
+	var msg = l.CodeMessage(c, tag)
+	if _, ok := c.(errors.MessageTemplater); !ok {
+		return msg
+	}
+
+	var emsg, eerr = errors.ExpandMessage(msg, errors.PublicMDs(errors.GetMD(err))...)
+	if eerr != nil {
+		return c.Message()
+	}
+
+	return emsg
+}
+
+// CodeMessage returns the message of c for the language tag, falling back in
+// the same way than Message, without filling its placeholders; it's only a
+// message template when c satisfies the errors.MessageTemplater interface.
+func (l *Localizer) CodeMessage(c errors.Code, tag language.Tag) string {
+	if l.Catalog != nil {
+		for t := tag; ; t = t.Parent() {
+			if msg, ok := l.Catalog.Lookup(t, c.String()); ok {
+				return msg
+			}
+
+			if t.IsRoot() {
+				break
+			}
+		}
+	}
+
+	if mt, ok := c.(errors.MessageTemplater); ok {
+		return mt.MessageTemplate()
+	}
+
+	return c.Message()
+}
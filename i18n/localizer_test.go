@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.fraixed.es/errors"
+	"golang.org/x/text/language"
+)
+
+func TestLocalizer_Message(t *testing.T) {
+	var c = NewCatalog()
+	c.Set(language.Spanish, "TestCode", "ha ocurrido un error de prueba")
+	c.Set(language.EuropeanSpanish, "TestCode", "ha ocurrido un error de prueba en España")
+
+	var (
+		l   = NewLocalizer(c)
+		err = fmt.Errorf("wrapped: %w", errors.New(testCode(true)))
+	)
+
+	var tcases = []struct {
+		desc   string
+		err    error
+		tag    language.Tag
+		expMsg string
+	}{
+		{
+			desc:   "exact language",
+			err:    err,
+			tag:    language.EuropeanSpanish,
+			expMsg: "ha ocurrido un error de prueba en España",
+		},
+		{
+			desc:   "parent language",
+			err:    err,
+			tag:    language.LatinAmericanSpanish,
+			expMsg: "ha ocurrido un error de prueba",
+		},
+		{
+			desc:   "parent language of a region",
+			err:    err,
+			tag:    language.MustParse("es-MX"),
+			expMsg: "ha ocurrido un error de prueba",
+		},
+		{
+			desc:   "code message",
+			err:    err,
+			tag:    language.French,
+			expMsg: testCode(true).Message(),
+		},
+		{
+			desc:   "error without code",
+			err:    fmt.Errorf("some error"),
+			tag:    language.Spanish,
+			expMsg: "some error",
+		},
+		{
+			desc:   "nil error",
+			tag:    language.Spanish,
+			expMsg: "",
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expMsg, l.Message(tc.err, tc.tag))
+		})
+	}
+
+	t.Run("template", func(t *testing.T) {
+		var c = NewCatalog()
+		c.Set(language.Spanish, "TmplTestCode", "error de prueba con {id} y {other}")
+
+		var err = errors.New(tmplTestCode(true),
+			errors.PublicMD("id", 7), errors.PublicMD("other", "otro"),
+		)
+		assert.Equal(t, "error de prueba con 7 y otro", NewLocalizer(c).Message(err, language.Spanish))
+		assert.Equal(t, "an test code error with 7 has happened", NewLocalizer(c).Message(err, language.French))
+	})
+
+	t.Run("template with internal metadata", func(t *testing.T) {
+		var c = NewCatalog()
+		c.Set(language.Spanish, "TmplTestCode", "error de prueba con {id} y {other}")
+
+		var err = errors.New(tmplTestCode(true), errors.PublicMD("id", 7), errors.MD{K: "other", V: "otro"})
+		assert.Equal(t, tmplTestCode(true).Message(), NewLocalizer(c).Message(err, language.Spanish))
+	})
+
+	t.Run("template with metadata of wrapped errors", func(t *testing.T) {
+		var c = NewCatalog()
+		c.Set(language.Spanish, "TmplTestCode", "error de prueba con {id} y {other}")
+
+		var err = errors.Wrap(
+			errors.New(testCode(true), errors.PublicMD("other", "otro")),
+			tmplTestCode(true), errors.PublicMD("id", 7),
+		)
+		assert.Equal(t, tmplTestCode(true).Message(), NewLocalizer(c).Message(err, language.Spanish))
+	})
+
+	t.Run("translation with braces of a code without template", func(t *testing.T) {
+		var c = NewCatalog()
+		c.Set(language.Spanish, "TestCode", "error de prueba con {id}")
+
+		var err = errors.New(testCode(true), errors.PublicMD("id", 7))
+		assert.Equal(t, "error de prueba con {id}", NewLocalizer(c).Message(err, language.Spanish))
+	})
+
+	t.Run("code message with braces", func(t *testing.T) {
+		var err = errors.New(bracesTestCode(true))
+		assert.Equal(t, bracesTestCode(true).Message(), NewLocalizer(c).Message(err, language.Spanish))
+	})
+
+	t.Run("without catalog", func(t *testing.T) {
+		assert.Equal(t, testCode(true).Message(), (&Localizer{}).Message(err, language.Spanish))
+	})
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.fraixed.es/errors"
+)
+
+// poEntry is an entry of a .po file which is being parsed.
+type poEntry struct {
+	ctxt, id, str strings.Builder
+	hasID, hasStr bool
+	fuzzy         bool
+}
+
+// parsePO parses the gettext .po file read from r and returns its messages
+// indexed by their msgid. The header, the entries with an empty msgstr and the
+// ones marked with the fuzzy flag, which aren't reviewed, are ignored as
+// gettext does.
+//
+// Only the msgctxt, which is ignored, msgid and msgstr keywords, the comments,
+// the flags and the continuation strings are supported.
+func parsePO(r io.Reader) (map[string]string, error) {
+	var (
+		msgs = map[string]string{}
+		s    = bufio.NewScanner(r)
+		e    = &poEntry{}
+		cur  *strings.Builder
+	)
+
+	var flush = func() {
+		if e.hasStr && !e.fuzzy && e.id.Len() > 0 && e.str.Len() > 0 {
+			msgs[e.id.String()] = e.str.String()
+		}
+
+		e = &poEntry{}
+		cur = nil
+	}
+
+	for ln := 1; s.Scan(); ln++ {
+		var line = strings.TrimSpace(s.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+
+		if strings.HasPrefix(line, "#,") {
+			// The flags precede the entry which they belong to.
+			if e.hasID {
+				flush()
+			}
+
+			for _, f := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(f) == "fuzzy" {
+					e.fuzzy = true
+				}
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var kw, val = "", line
+		if !strings.HasPrefix(line, `"`) {
+			kw, val = line, ""
+			if i := strings.IndexByte(line, ' '); i >= 0 {
+				kw, val = line[:i], strings.TrimSpace(line[i+1:])
+			}
+		}
+
+		switch kw {
+		case "msgctxt":
+			if e.hasID {
+				flush()
+			}
+
+			cur = &e.ctxt
+		case "msgid":
+			if e.hasID {
+				flush()
+			}
+
+			e.hasID = true
+			cur = &e.id
+		case "msgstr":
+			if !e.hasID || e.hasStr {
+				return nil, errors.New(ErrCodeMalformedCatalog, errors.MD{K: "line", V: ln})
+			}
+
+			e.hasStr = true
+			cur = &e.str
+		case "":
+			if cur == nil {
+				return nil, errors.New(ErrCodeMalformedCatalog, errors.MD{K: "line", V: ln})
+			}
+		default:
+			return nil, errors.New(ErrCodeMalformedCatalog,
+				errors.MD{K: "line", V: ln}, errors.MD{K: "keyword", V: kw},
+			)
+		}
+
+		var uv, err = strconv.Unquote(val)
+		if err != nil {
+			return nil, errors.Wrap(err, ErrCodeMalformedCatalog, errors.MD{K: "line", V: ln})
+		}
+
+		cur.WriteString(uv)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, ErrCodeMalformedCatalog)
+	}
+
+	flush()
+	return msgs, nil
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+	"golang.org/x/text/language"
+)
+
+func TestCatalog_LoadPO(t *testing.T) {
+	var c = NewCatalog()
+	c.Registry = errors.NewRegistry()
+	c.Registry.MustRegister(tmplTestCode(true))
+
+	var err = c.LoadPO(language.Spanish, strings.NewReader("msgid \"TmplTestCode\"\nmsgstr \"error {id\""))
+	assert.True(t, errors.Is(err, ErrCodeMalformedCatalog))
+	assert.Empty(t, c.Languages())
+}
+
+func TestParsePO(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var msgs, err = parsePO(strings.NewReader(`
+msgctxt "errors"
+msgid "TestCode"
+msgstr "error de \"prueba\"\n"
+msgid "OtherCode"
+msgstr "otro error"
+#, fuzzy
+msgid "FuzzyCode"
+msgstr "error sin revisar"
+
+#, c-format
+msgid "FormatCode"
+msgstr "error con formato"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"TestCode":   "error de \"prueba\"\n",
+			"OtherCode":  "otro error",
+			"FormatCode": "error con formato",
+		}, msgs)
+	})
+
+	var tcases = []struct {
+		desc string
+		po   string
+	}{
+		{desc: "msgstr without msgid", po: `msgstr "error"`},
+		{desc: "two msgstr", po: "msgid \"TestCode\"\nmsgstr \"a\"\nmsgstr \"b\""},
+		{desc: "string without keyword", po: `"error"`},
+		{desc: "unsupported keyword", po: "msgid \"TestCode\"\nmsgid_plural \"TestCodes\""},
+		{desc: "unquoted string", po: "msgid TestCode"},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var _, err = parsePO(strings.NewReader(tc.po))
+			assert.True(t, errors.Is(err, ErrCodeMalformedCatalog))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package i18n
+
+// testCode is a silly example of a Code implementation with the only purpose of
+// testing this package.
+type testCode bool
+
+func (testCode) String() string {
+	return "TestCode"
+}
+
+func (testCode) Message() string {
+	return "an test code error has happened"
+}
+
+// bracesTestCode is a silly example of a Code implementation, whose message
+// has braces but it isn't a template, with the only purpose of testing this
+// package.
+type bracesTestCode bool
+
+func (bracesTestCode) String() string {
+	return "BracesTestCode"
+}
+
+func (bracesTestCode) Message() string {
+	return "an {unexpected} test code error has happened"
+}
+
+// tmplTestCode is a silly example of a Code implementation with the only
+// purpose of testing the message templates.
+type tmplTestCode bool
+
+func (tmplTestCode) String() string {
+	return "TmplTestCode"
+}
+
+func (tmplTestCode) Message() string {
+	return "an test code error with template has happened"
+}
+
+func (tmplTestCode) MessageTemplate() string {
+	return "an test code error with {id} has happened"
+}
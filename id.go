@@ -0,0 +1,251 @@
+package errors
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ID is the unique identifier of an error instance.
+// Its format depends on the IDGenerator which generated it; the zero value
+// means that there isn't any ID.
+type ID string
+
+// String returns the string representation of the ID.
+func (id ID) String() string {
+	return string(id)
+}
+
+// IDGenerator generates the IDs of the errors. See WithIDGenerator.
+// Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	NewID() ID
+}
+
+// IDGeneratorFunc is an adapter to allow the use of ordinary functions as
+// IDGenerator.
+type IDGeneratorFunc func() ID
+
+// NewID calls fn.
+func (fn IDGeneratorFunc) NewID() ID {
+	return fn()
+}
+
+// NewUUIDv4Generator returns an IDGenerator which generates random UUIDs
+// (version 4), in their canonical textual representation.
+func NewUUIDv4Generator() IDGenerator {
+	return IDGeneratorFunc(func() ID {
+		var u [16]byte
+		_, _ = rand.Read(u[:])
+
+		u[6] = (u[6] & 0x0f) | 0x40 // Version 4
+		u[8] = (u[8] & 0x3f) | 0x80 // Variant RFC 9562
+
+		return ID(formatUUID(u))
+	})
+}
+
+// NewUUIDv7Generator returns an IDGenerator which generates time-ordered UUIDs
+// (version 7), in their canonical textual representation, hence the IDs sort
+// by their generation time.
+// The IDs generated in the same millisecond are monotonic because the 12 bits
+// following the timestamp are used as a counter, which starts from a random
+// value on each millisecond.
+// This is the IDGenerator used by default.
+func NewUUIDv7Generator() IDGenerator {
+	return &uuidv7Generator{now: time.Now}
+}
+
+type uuidv7Generator struct {
+	mu  sync.Mutex
+	now func() time.Time
+	ms  uint64
+	seq uint16
+}
+
+func (g *uuidv7Generator) NewID() ID {
+	var u [16]byte
+	_, _ = rand.Read(u[6:])
+
+	g.mu.Lock()
+	var ms = uint64(g.now().UnixNano() / int64(time.Millisecond))
+	if ms > g.ms {
+		g.ms = ms
+		// Use 11 random bits for leaving room to the counter.
+		g.seq = binary.BigEndian.Uint16(u[6:8]) & 0x07ff
+	} else {
+		g.seq++
+		if g.seq > 0x0fff {
+			g.ms++
+			g.seq = 0
+		}
+	}
+
+	ms = g.ms
+	var seq = g.seq
+	g.mu.Unlock()
+
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = 0x70 | byte(seq>>8) // Version 7
+	u[7] = byte(seq)
+	u[8] = (u[8] & 0x3f) | 0x80 // Variant RFC 9562
+
+	return ID(formatUUID(u))
+}
+
+func formatUUID(u [16]byte) string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+
+	return string(b[:])
+}
+
+// crockford is the Crockford's base32 alphabet used by the ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewULIDGenerator returns an IDGenerator which generates ULIDs (Universally
+// Unique Lexicographically Sortable Identifiers), hence the IDs sort by their
+// generation time.
+// The IDs generated in the same millisecond are monotonic because the random
+// part is incremented rather than generated again.
+func NewULIDGenerator() IDGenerator {
+	return &ulidGenerator{now: time.Now}
+}
+
+type ulidGenerator struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	ms   uint64
+	rand [10]byte
+}
+
+func (g *ulidGenerator) NewID() ID {
+	var u [16]byte
+
+	g.mu.Lock()
+	var ms = uint64(g.now().UnixNano() / int64(time.Millisecond))
+	if ms > g.ms {
+		g.ms = ms
+		_, _ = rand.Read(g.rand[:])
+	} else {
+		// Increment the random part; on overflow, move to the next millisecond.
+		var i = len(g.rand) - 1
+		for ; i >= 0; i-- {
+			g.rand[i]++
+			if g.rand[i] != 0 {
+				break
+			}
+		}
+
+		if i < 0 {
+			g.ms++
+		}
+	}
+
+	ms = g.ms
+	copy(u[6:], g.rand[:])
+	g.mu.Unlock()
+
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+
+	// 128 bits are encoded in 26 characters of 5 bits, the first one only has
+	// 3 bits.
+	var (
+		b   [26]byte
+		hi  = binary.BigEndian.Uint64(u[0:8])
+		lo  = binary.BigEndian.Uint64(u[8:16])
+		pos = len(b) - 1
+	)
+
+	for ; pos >= 0; pos-- {
+		b[pos] = crockford[lo&0x1f]
+		lo = (lo >> 5) | (hi << 59)
+		hi >>= 5
+	}
+
+	return ID(b[:])
+}
+
+// snowflakeEpoch is the epoch of the snowflake IDs (2020-01-01T00:00:00Z) in
+// milliseconds since the Unix epoch.
+const snowflakeEpoch = 1577836800000
+
+// NewSnowflakeGenerator returns an IDGenerator which generates snowflake-style
+// 64 bits IDs, composed by 41 bits for the milliseconds since
+// 2020-01-01T00:00:00Z, 10 bits for node and 12 bits for a sequence number,
+// in their decimal representation padded with zeros to 19 digits, hence the
+// IDs sort by their generation time.
+// node must be unique for each process which generates IDs and only its 10 less
+// significant bits are used.
+func NewSnowflakeGenerator(node uint16) IDGenerator {
+	return &snowflakeGenerator{now: time.Now, node: uint64(node & 0x03ff)}
+}
+
+type snowflakeGenerator struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	node uint64
+	ms   uint64
+	seq  uint64
+}
+
+func (g *snowflakeGenerator) NewID() ID {
+	g.mu.Lock()
+	var ms = uint64(g.now().UnixNano()/int64(time.Millisecond)) - snowflakeEpoch
+	if ms > g.ms {
+		g.ms = ms
+		g.seq = 0
+	} else {
+		g.seq++
+		if g.seq > 0x0fff {
+			g.ms++
+			g.seq = 0
+		}
+	}
+
+	var id = (g.ms&0x01ffffffffff)<<22 | g.node<<12 | g.seq
+	g.mu.Unlock()
+
+	return ID(fmt.Sprintf("%019d", id))
+}
+
+// NewCounterGenerator returns an IDGenerator which generates deterministic IDs
+// from a counter which starts at start, in their decimal representation padded
+// with zeros to 20 digits. It's meant to be used in tests.
+func NewCounterGenerator(start uint64) IDGenerator {
+	var (
+		mu sync.Mutex
+		n  = start
+	)
+
+	return IDGeneratorFunc(func() ID {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var id = ID(fmt.Sprintf("%020d", n))
+		n++
+
+		return id
+	})
+}
@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUUIDv4Generator(t *testing.T) {
+	var (
+		g  = NewUUIDv4Generator()
+		re = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	)
+
+	var id1, id2 = g.NewID(), g.NewID()
+	assert.Regexp(t, re, id1.String())
+	assert.Regexp(t, re, id2.String())
+	assert.NotEqual(t, id1, id2)
+}
+
+func TestNewUUIDv7Generator(t *testing.T) {
+	var (
+		now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		g   = &uuidv7Generator{now: func() time.Time { return now }}
+		re  = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	)
+
+	var id = g.NewID()
+	assert.Regexp(t, re, id.String())
+	// 2024-05-01T10:00:00Z is 1714557600000 milliseconds since the Unix epoch.
+	assert.Equal(t, "018f3398-c100", id.String()[:13])
+
+	t.Run("sorted by time", func(t *testing.T) {
+		testIDsSorted(t, func(d time.Duration) ID {
+			now = now.Add(d)
+			return g.NewID()
+		})
+	})
+
+	t.Run("counter overflow", func(t *testing.T) {
+		var g = &uuidv7Generator{now: func() time.Time { return now }}
+		var ids = make([]ID, 5000)
+		for i := range ids {
+			ids[i] = g.NewID()
+		}
+
+		assert.True(t, sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i] < ids[j] }))
+		assert.NotEqual(t, ids[0][:13], ids[len(ids)-1][:13])
+	})
+}
+
+func TestNewULIDGenerator(t *testing.T) {
+	var (
+		now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		g   = &ulidGenerator{now: func() time.Time { return now }}
+		re  = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	)
+
+	var id = g.NewID()
+	assert.Regexp(t, re, id.String())
+	// 1714557600000 milliseconds encoded in 10 Crockford's base32 characters.
+	assert.Equal(t, "01HWSSHG80", id.String()[:10])
+
+	t.Run("sorted by time", func(t *testing.T) {
+		testIDsSorted(t, func(d time.Duration) ID {
+			now = now.Add(d)
+			return g.NewID()
+		})
+	})
+}
+
+func TestNewSnowflakeGenerator(t *testing.T) {
+	var (
+		now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		g   = NewSnowflakeGenerator(5).(*snowflakeGenerator)
+	)
+	g.now = func() time.Time { return now }
+
+	var id = g.NewID()
+	assert.Regexp(t, `^[0-9]{19}$`, id.String())
+	// (1714557600000 - 1577836800000) << 22 | 5 << 12 | 0
+	assert.Equal(t, "0573448598323220480", id.String())
+	assert.Equal(t, "0573448598323220481", g.NewID().String())
+
+	t.Run("sorted by time", func(t *testing.T) {
+		testIDsSorted(t, func(d time.Duration) ID {
+			now = now.Add(d)
+			return g.NewID()
+		})
+	})
+}
+
+func TestNewCounterGenerator(t *testing.T) {
+	var g = NewCounterGenerator(10)
+	assert.Equal(t, "00000000000000000010", g.NewID().String())
+	assert.Equal(t, "00000000000000000011", g.NewID().String())
+}
+
+func TestIDGenerators_concurrency(t *testing.T) {
+	var gens = map[string]IDGenerator{
+		"uuidv4":    NewUUIDv4Generator(),
+		"uuidv7":    NewUUIDv7Generator(),
+		"ulid":      NewULIDGenerator(),
+		"snowflake": NewSnowflakeGenerator(1),
+		"counter":   NewCounterGenerator(0),
+	}
+
+	for name, g := range gens {
+		var g = g
+		t.Run(name, func(t *testing.T) {
+			var (
+				wg  sync.WaitGroup
+				mu  sync.Mutex
+				ids = map[ID]struct{}{}
+			)
+
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						var id = g.NewID()
+						mu.Lock()
+						ids[id] = struct{}{}
+						mu.Unlock()
+					}
+				}()
+			}
+
+			wg.Wait()
+			require.Len(t, ids, 1000)
+		})
+	}
+}
+
+// testIDsSorted checks that the IDs returned by newID, which receives the
+// duration to advance the clock, sort by their generation time, including the
+// ones generated in the same millisecond.
+func testIDsSorted(t *testing.T, newID func(time.Duration) ID) {
+	var ids []ID
+	for _, d := range []time.Duration{0, 0, 0, time.Millisecond, 0, time.Second, time.Hour, 0} {
+		ids = append(ids, newID(d))
+	}
+
+	assert.True(t, sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i] < ids[j] }), "%v", ids)
+
+	for i := 1; i < len(ids); i++ {
+		assert.NotEqual(t, ids[i-1], ids[i])
+	}
+}
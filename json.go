@@ -0,0 +1,251 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonError is the JSON representation of the errors.
+// Code and ID are empty when the error isn't created by any of the constructors
+// of this package. Message is the Code message or the error message when the
+// error isn't created by any of the constructors of this package.
+type jsonError struct {
+	Code      string       `json:"code,omitempty"`
+	Message   string       `json:"message"`
+	ID        string       `json:"id,omitempty"`
+	Metadata  []jsonMD     `json:"metadata,omitempty"`
+	Wrapped   *jsonError   `json:"wrapped,omitempty"`
+	Errors    []*jsonError `json:"errors,omitempty"`
+	CallStack []jsonFrame  `json:"call_stack,omitempty"`
+}
+
+// jsonMD is the JSON representation of a MD.
+type jsonMD struct {
+	Key         string          `json:"key"`
+	Value       json.RawMessage `json:"value"`
+	Sensitivity string          `json:"sensitivity,omitempty"`
+}
+
+// jsonFrame is the JSON representation of a call stack frame.
+type jsonFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// It's the same output than ToJSON(err, false).
+func (err derror) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newJSONError(err, false))
+}
+
+// ToJSON returns the JSON representation of err, which is a JSON object with
+// the following fields:
+//
+//	code: the string representation of the Code.
+//	message: the message of the Code, without expanding its placeholders, so
+//	         the Code can be compared after reconstructing the error.
+//	id: the ID.
+//	metadata: an array of objects with the fields "key", "value" and
+//	          "sensitivity", which is omitted when it's internal. The values
+//	          which cannot be represented in JSON are represented by their
+//	          string representation (fmt "%+v") and the secret ones by
+//	          RedactedValue.
+//	wrapped: the wrapped error, with the same fields.
+//	call_stack: an array of objects with the fields "function", "file" and
+//	            "line". It's only present when withCallStack is true.
+//
+// Errors which aren't created by any of the constructors of this package are
+// represented with the field "message", containing their error message, and
+// the field "wrapped" if they wrap another error or the field "errors", with
+// an array of them, if they aggregate several errors (see Join).
+func ToJSON(err error, withCallStack bool) ([]byte, error) {
+	return json.Marshal(newJSONError(err, withCallStack))
+}
+
+// FromJSON creates an error from data, which must be the JSON representation
+// of an error as returned by ToJSON.
+// The Codes are obtained from r, or from the default registry when r is nil,
+// (see Registry.Resolve) so Is, GetCode, GetID, etc. work with the returned
+// error as they do with the original one.
+// The returned error doesn't have call stack and its metadata values are the
+// values which result of decoding JSON into an interface{} value (see
+// json.Unmarshal).
+//
+// The second returned value is an error with the ErrCodeMalformedJSON Code when
+// data isn't a valid JSON representation, in such case, the first returned
+// value is nil.
+func FromJSON(data []byte, r *Registry) (error, error) {
+	if r == nil {
+		r = defaultRegistry
+	}
+
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return nil, Wrap(err, ErrCodeMalformedJSON)
+	}
+
+	return je.toError(r)
+}
+
+func newJSONError(err error, withCallStack bool) *jsonError {
+	if err == nil {
+		return nil
+	}
+
+	var derr, ok = err.(derror)
+	if !ok {
+		var je = &jsonError{
+			Message: err.Error(),
+		}
+
+		switch uerr := err.(type) {
+		case interface{ Unwrap() error }:
+			je.Wrapped = newJSONError(uerr.Unwrap(), withCallStack)
+		case interface{ Unwrap() []error }:
+			for _, e := range uerr.Unwrap() {
+				if e != nil {
+					je.Errors = append(je.Errors, newJSONError(e, withCallStack))
+				}
+			}
+		}
+
+		return je
+	}
+
+	var je = &jsonError{
+		Code:    derr.c.String(),
+		Message: derr.c.Message(),
+		ID:      derr.id.String(),
+		Wrapped: newJSONError(derr.werr, withCallStack),
+	}
+
+	for _, md := range derr.mds {
+		var v, err = json.Marshal(md.value())
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprintf("%+v", md.V))
+		}
+
+		var jmd = jsonMD{Key: md.K, Value: v}
+		if md.S != SensitivityInternal {
+			jmd.Sensitivity = md.S.String()
+		}
+
+		je.Metadata = append(je.Metadata, jmd)
+	}
+
+	if withCallStack {
+		for _, f := range derr.cs.frames() {
+			je.CallStack = append(je.CallStack, jsonFrame{
+				Function: f.Function,
+				File:     f.File,
+				Line:     f.Line,
+			})
+		}
+	}
+
+	return je
+}
+
+func (je *jsonError) toError(r *Registry) (error, error) {
+	var (
+		werr error
+		err  error
+	)
+
+	if je.Wrapped != nil {
+		werr, err = je.Wrapped.toError(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if je.Code == "" {
+		if len(je.Errors) == 0 {
+			return remoteError{msg: je.Message, werr: werr}, nil
+		}
+
+		var errs = make([]error, len(je.Errors))
+		for i, cje := range je.Errors {
+			var cerr, err = cje.toError(r)
+			if err != nil {
+				return nil, err
+			}
+
+			errs[i] = cerr
+		}
+
+		return remoteMultiError{msg: je.Message, errs: errs}, nil
+	}
+
+	if je.ID == "" {
+		return nil, New(ErrCodeMalformedJSON, MD{K: "code", V: je.Code})
+	}
+
+	var mds = make(mDatas, 0, len(je.Metadata))
+	for _, jmd := range je.Metadata {
+		var v interface{}
+		if len(jmd.Value) > 0 {
+			if err := json.Unmarshal(jmd.Value, &v); err != nil {
+				return nil, Wrap(err, ErrCodeMalformedJSON, MD{K: "metadata", V: jmd.Key})
+			}
+		}
+
+		var md = MD{K: jmd.Key, V: v}
+		switch jmd.Sensitivity {
+		case "", SensitivityInternal.String():
+		case SensitivityPublic.String():
+			md.S = SensitivityPublic
+		case SensitivitySecret.String():
+			md.S = SensitivitySecret
+		default:
+			return nil, New(ErrCodeMalformedJSON,
+				MD{K: "metadata", V: jmd.Key}, MD{K: "sensitivity", V: jmd.Sensitivity},
+			)
+		}
+
+		mds = append(mds, md)
+	}
+
+	if len(mds) == 0 {
+		mds = nil
+	}
+
+	return Restore(r.Resolve(je.Code, je.Message), ID(je.ID), werr, mds...), nil
+}
+
+// remoteError is the error used to reconstruct the errors, decoded from their
+// JSON representation, which weren't created by any of the constructors of
+// this package.
+type remoteError struct {
+	msg  string
+	werr error
+}
+
+// Error satisfies the standard error interface.
+func (err remoteError) Error() string {
+	return err.msg
+}
+
+// Unwrap returns the error wrapped by err or nil if it doesn't wrap any.
+func (err remoteError) Unwrap() error {
+	return err.werr
+}
+
+// remoteMultiError is the error used to reconstruct the errors, decoded from
+// their JSON representation, which weren't created by any of the constructors
+// of this package and aggregate several errors.
+type remoteMultiError struct {
+	msg  string
+	errs []error
+}
+
+// Error satisfies the standard error interface.
+func (err remoteMultiError) Error() string {
+	return err.msg
+}
+
+// Unwrap returns the aggregated errors.
+func (err remoteMultiError) Unwrap() []error {
+	return err.errs
+}
@@ -0,0 +1,183 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerror_MarshalJSON(t *testing.T) {
+	var (
+		err = Wrap(
+			fmt.Errorf("some context: %w", New(differentTestCode(true), MD{K: "n", V: 10})),
+			testCode(true),
+			MD{K: "var1", V: "a string"}, MD{K: "fn", V: func() {}}, PublicMD("pub", true),
+			SecretMD("email", "ivan@example.com"),
+		)
+		derr  = err.(derror)
+		inner = errors.Unwrap(derr.werr).(derror)
+	)
+
+	var b, jerr = json.Marshal(err)
+	require.NoError(t, jerr)
+
+	var exp = fmt.Sprintf(`{
+		"code": "TestCode",
+		"message": "an test code error has happened",
+		"id": %q,
+		"metadata": [
+			{"key": "var1", "value": "a string"},
+			{"key": "fn", "value": %q},
+			{"key": "pub", "value": true, "sensitivity": "public"},
+			{"key": "email", "value": "[REDACTED]", "sensitivity": "secret"}
+		],
+		"wrapped": {
+			"message": %q,
+			"wrapped": {
+				"code": "DifferentError",
+				"message": "This is a different error",
+				"id": %q,
+				"metadata": [{"key": "n", "value": 10}]
+			}
+		}
+	}`, derr.id, fmt.Sprintf("%+v", derr.mds[1].V), derr.werr.Error(), inner.id)
+
+	assert.JSONEq(t, exp, string(b))
+}
+
+func TestToJSON(t *testing.T) {
+	t.Run("with call stack", func(t *testing.T) {
+		var (
+			err   = New(testCode(true))
+			b, je = ToJSON(err, true)
+			v     jsonError
+		)
+		require.NoError(t, je)
+		require.NoError(t, json.Unmarshal(b, &v))
+
+		require.NotEmpty(t, v.CallStack)
+		assert.Contains(t, v.CallStack[0].Function, "errors.TestToJSON")
+		assert.True(t, strings.HasSuffix(v.CallStack[0].File, "json_test.go"))
+		assert.NotZero(t, v.CallStack[0].Line)
+	})
+
+	t.Run("without call stack", func(t *testing.T) {
+		var b, je = ToJSON(New(testCode(true)), false)
+		require.NoError(t, je)
+		assert.NotContains(t, string(b), "call_stack")
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		var b, je = ToJSON(errors.New("some error"), true)
+		require.NoError(t, je)
+		assert.JSONEq(t, `{"message": "some error"}`, string(b))
+	})
+}
+
+func TestFromJSON(t *testing.T) {
+	var r = NewRegistry()
+	r.MustRegister(testCode(true))
+
+	t.Run("round trip", func(t *testing.T) {
+		var (
+			inner = New(differentTestCode(true), MD{K: "n", V: 10})
+			err   = Wrap(fmt.Errorf("some context: %w", inner), testCode(true),
+				MD{K: "var1", V: "a string"}, PublicMD("var2", "public"), SecretMD("var3", "secret"),
+			)
+		)
+
+		var b, je = ToJSON(err, true)
+		require.NoError(t, je)
+
+		var derr, perr = FromJSON(b, r)
+		require.NoError(t, perr)
+
+		var c, _ = GetCode(derr)
+		assert.Equal(t, testCode(true), c)
+		assert.True(t, Is(derr, testCode(true)))
+		assert.True(t, HasCode(derr, differentTestCode(true)))
+
+		var id, _ = GetID(derr)
+		assert.Equal(t, err.(derror).id, id)
+		assert.Equal(t,
+			[]MD{
+				{K: "var1", V: "a string"},
+				{K: "var2", V: "public", S: SensitivityPublic},
+				{K: "var3", V: RedactedValue, S: SensitivitySecret},
+			},
+			GetMD(derr),
+		)
+
+		var v, _ = LookupMD(derr, "n")
+		assert.Equal(t, float64(10), v)
+
+		var iid, _ = GetID(errors.Unwrap(derr))
+		assert.Equal(t, inner.(derror).id, iid)
+
+		assert.Equal(t, err.Error(), derr.Error())
+		assert.Equal(t, errors.Unwrap(err).Error(), errors.Unwrap(derr).Error())
+		assert.Empty(t, derr.(derror).cs)
+	})
+
+	t.Run("code isn't registered", func(t *testing.T) {
+		var (
+			derr, perr = FromJSON([]byte(`{
+				"code": "NotRegistered",
+				"message": "not registered code",
+				"id": "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b"
+			}`), r)
+		)
+		require.NoError(t, perr)
+
+		var c, _ = GetCode(derr)
+		assert.Equal(t, "NotRegistered", c.String())
+		assert.Equal(t, "not registered code", c.Message())
+	})
+
+	t.Run("default registry", func(t *testing.T) {
+		var b, je = ToJSON(New(ErrCodeDuplicated), false)
+		require.NoError(t, je)
+
+		var derr, perr = FromJSON(b, nil)
+		require.NoError(t, perr)
+
+		var c, _ = GetCode(derr)
+		assert.Equal(t, ErrCodeDuplicated, c)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		var tcases = []struct {
+			desc string
+			data string
+		}{
+			{desc: "invalid JSON", data: `{"code": `},
+			{desc: "without ID", data: `{"code": "TestCode"}`},
+			{
+				desc: "invalid metadata sensitivity",
+				data: `{
+					"code": "TestCode",
+					"id": "a0d2dbe9-4aa9-47d2-9630-2cde8a3b1a0b",
+					"metadata": [{"key": "k", "value": 1, "sensitivity": "unknown"}]
+				}`,
+			},
+			{
+				desc: "wrapped without ID",
+				data: `{"message": "some error", "wrapped": {"code": "TestCode"}}`,
+			},
+		}
+
+		for i := range tcases {
+			var tc = tcases[i]
+			t.Run(tc.desc, func(t *testing.T) {
+				var derr, perr = FromJSON([]byte(tc.data), r)
+				assert.Nil(t, derr)
+				assert.True(t, Is(perr, ErrCodeMalformedJSON))
+			})
+		}
+	})
+}
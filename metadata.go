@@ -6,19 +6,78 @@ import (
 )
 
 // MD is a key/value pair to add metadata to an error.
+// S indicates who can see the metadata; its zero value is SensitivityInternal,
+// so metadata is only exposed to users when it's explicitly marked as public.
 type MD struct {
 	K string
 	V interface{}
+	S Sensitivity
+}
+
+// Sensitivity indicates who can see a metadata.
+type Sensitivity uint8
+
+const (
+	// SensitivityInternal is for metadata which is only for the operations team
+	// and maintainers, hence it must not be exposed to the users.
+	SensitivityInternal Sensitivity = iota
+	// SensitivityPublic is for metadata which can be exposed to the users, for
+	// example, in the HTTP responses.
+	SensitivityPublic
+	// SensitivitySecret is for metadata which must not be exposed to anybody,
+	// for example, personal data or credentials. Its value is masked (see
+	// RedactedValue) in all the outputs of this package (the Format verbs,
+	// JSON, slog and message templates), but it's available through the
+	// metadata accessors (see GetMD, LookupMD and AllMD).
+	SensitivitySecret
+)
+
+// RedactedValue is the value shown in place of the values of the metadata
+// which are marked as secret (see SensitivitySecret).
+const RedactedValue = "[REDACTED]"
+
+// String returns the name of the sensitivity.
+func (s Sensitivity) String() string {
+	switch s {
+	case SensitivityInternal:
+		return "internal"
+	case SensitivityPublic:
+		return "public"
+	case SensitivitySecret:
+		return "secret"
+	default:
+		return fmt.Sprintf("Sensitivity(%d)", uint8(s))
+	}
+}
+
+// PublicMD returns a MD with k and v marked as public (see SensitivityPublic).
+func PublicMD(k string, v interface{}) MD {
+	return MD{K: k, V: v, S: SensitivityPublic}
+}
+
+// SecretMD returns a MD with k and v marked as secret (see SensitivitySecret).
+func SecretMD(k string, v interface{}) MD {
+	return MD{K: k, V: v, S: SensitivitySecret}
 }
 
 // Format satisfies the fmt.Formatter interface.
 // It only prints when 'v' verb is used.
+// The value of the secret metadata is printed as RedactedValue.
 func (md MD) Format(state fmt.State, verb rune) {
 	if verb != 'v' {
 		return
 	}
 
-	_, _ = fmt.Fprintf(state, "{%q: %+v}", md.K, md.V)
+	_, _ = fmt.Fprintf(state, "{%q: %+v}", md.K, md.value())
+}
+
+// value returns the value of md or RedactedValue if md is secret.
+func (md MD) value() interface{} {
+	if md.S == SensitivitySecret {
+		return RedactedValue
+	}
+
+	return md.V
 }
 
 // mDatas is a MD slice which allows to internally break the logic between
@@ -33,6 +33,12 @@ func TestMD_Format(t *testing.T) {
 			md:     MD{K: "a key", V: 10.5},
 			expout: "{\"a key\": 10.5}",
 		},
+		{
+			desc:   "'v' verb when it's secret",
+			format: "%v",
+			md:     SecretMD("password", "1234"),
+			expout: "{\"password\": [REDACTED]}",
+		},
 		{
 			desc: "any other verb",
 			format: func() string {
@@ -105,3 +111,15 @@ func TestMDatas_Format(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicMD(t *testing.T) {
+	assert.Equal(t, MD{K: "a-key", V: 10, S: SensitivityPublic}, PublicMD("a-key", 10))
+	assert.Equal(t, "public", SensitivityPublic.String())
+	assert.Equal(t, "internal", MD{}.S.String())
+	assert.Equal(t, "Sensitivity(20)", Sensitivity(20).String())
+}
+
+func TestSecretMD(t *testing.T) {
+	assert.Equal(t, MD{K: "a-key", V: 10, S: SensitivitySecret}, SecretMD("a-key", 10))
+	assert.Equal(t, "secret", SensitivitySecret.String())
+}
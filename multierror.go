@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// multiError is the internal error type used by this package for aggregating
+// several errors.
+type multiError struct {
+	errs []error
+}
+
+// Join returns an error which aggregates errs, discarding the nil ones; if all
+// of them are nil, it returns nil. The errors of errs which are aggregates
+// created by Join or Append are flattened, so their errors are aggregated
+// rather than themselves.
+// The returned error is unwrapped as a slice of errors (see the standard errors
+// package documentation), hence Is, GetCode, etc. consider its errors.
+func Join(errs ...error) error {
+	var merr multiError
+	merr.append(errs...)
+
+	if len(merr.errs) == 0 {
+		return nil
+	}
+
+	return merr
+}
+
+// Append returns an error which aggregates err and errs, with the same rules
+// than Join. It's meant to be used for collecting errors in a loop, being err
+// the error returned by the previous call, which isn't modified.
+func Append(err error, errs ...error) error {
+	var merr multiError
+	merr.append(err)
+	merr.append(errs...)
+
+	if len(merr.errs) == 0 {
+		return nil
+	}
+
+	return merr
+}
+
+func (merr *multiError) append(errs ...error) {
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case multiError:
+			merr.errs = append(merr.errs, e.errs...)
+		default:
+			merr.errs = append(merr.errs, err)
+		}
+	}
+}
+
+// Unwrap returns the aggregated errors.
+func (merr multiError) Unwrap() []error {
+	var errs = make([]error, len(merr.errs))
+	copy(errs, merr.errs)
+
+	return errs
+}
+
+// Format satisfies the fmt.Formatter interface.
+// With the 's' verb, it prints the number of errors followed by the errors
+// separated by semicolons, printing the errors created by one of the
+// constructors of this package with their ID.
+// With the 'v' verb, it prints the number of errors followed by each error,
+// in a new line, with the same verb and flags.
+// Any other verb, it prints nothing.
+func (merr multiError) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		var ss = make([]string, len(merr.errs))
+		for i, err := range merr.errs {
+			if derr, ok := err.(derror); ok {
+				ss[i] = fmt.Sprintf("%s (id: %s)", derr, derr.id)
+			} else {
+				ss[i] = err.Error()
+			}
+		}
+
+		_, _ = fmt.Fprintf(state, "%d errors occurred: %s", len(merr.errs), strings.Join(ss, "; "))
+	case 'v':
+		var f = "%v"
+		switch {
+		case state.Flag('+') && state.Flag('-'):
+			f = "%+-v"
+		case state.Flag('+'):
+			f = "%+v"
+		}
+
+		_, _ = fmt.Fprintf(state, "%d errors occurred:", len(merr.errs))
+		for _, err := range merr.errs {
+			var s = strings.Replace(fmt.Sprintf(f, err), "\n", "\n\t", -1)
+			_, _ = fmt.Fprintf(state, "\n\t* %s", s)
+		}
+	}
+}
+
+// Error satisfies the standard error interface.
+// It returns a string which is the same output than fmt.Printf("%s", err).
+func (merr multiError) Error() string {
+	return fmt.Sprintf("%s", merr)
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// It's the same output than ToJSON(merr, false).
+func (merr multiError) MarshalJSON() ([]byte, error) {
+	return ToJSON(merr, false)
+}
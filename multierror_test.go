@@ -0,0 +1,169 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	t.Run("several errors", func(t *testing.T) {
+		var (
+			err1 = New(testCode(true))
+			err2 = errors.New("some error")
+			err  = Join(err1, nil, err2)
+		)
+		require.IsType(t, multiError{}, err)
+		assert.Equal(t, []error{err1, err2}, err.(multiError).errs)
+	})
+
+	t.Run("flatten aggregates", func(t *testing.T) {
+		var (
+			err1 = New(testCode(true))
+			err2 = New(differentTestCode(true))
+			err3 = errors.New("some error")
+			err  = Join(Join(err1, err2), err3)
+		)
+		assert.Equal(t, []error{err1, err2, err3}, err.(multiError).errs)
+	})
+
+	t.Run("nil errors", func(t *testing.T) {
+		assert.Nil(t, Join())
+		assert.Nil(t, Join(nil, nil))
+	})
+}
+
+func TestAppend(t *testing.T) {
+	var err error
+	for _, e := range []error{nil, New(testCode(true)), nil, errors.New("some error")} {
+		err = Append(err, e)
+	}
+
+	require.IsType(t, multiError{}, err)
+	assert.Len(t, err.(multiError).errs, 2)
+
+	var err2 = Append(err, New(differentTestCode(true)), New(otherTestCode(true)))
+	assert.Len(t, err2.(multiError).errs, 4)
+	// The previous error isn't modified
+	assert.Len(t, err.(multiError).errs, 2)
+
+	assert.Nil(t, Append(nil))
+	assert.Nil(t, Append(nil, nil))
+
+	var single = New(testCode(true))
+	assert.Equal(t, []error{single}, Append(single).(multiError).errs)
+}
+
+func TestMultiError_operators(t *testing.T) {
+	var (
+		err1 = New(testCode(true), MD{K: "a", V: 1})
+		err2 = Wrap(New(otherTestCode(true)), differentTestCode(true))
+		err  = Join(errors.New("some error"), err1, err2)
+	)
+
+	assert.True(t, Is(err, testCode(true)))
+	assert.True(t, Is(err, differentTestCode(true)))
+	assert.False(t, Is(err, otherTestCode(true)))
+	assert.True(t, HasCode(err, otherTestCode(true)))
+	assert.True(t, errors.Is(err, err1))
+
+	var c, ok = GetCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, testCode(true), c)
+
+	var id, _ = GetID(err)
+	assert.Equal(t, err1.(derror).id, id)
+
+	c, ok = GetCodeFunc(err, func(c, than Code) bool { return c.String() < than.String() })
+	assert.True(t, ok)
+	assert.Equal(t, differentTestCode(true), c)
+
+	_, ok = GetCodeFunc(Join(errors.New("some error")), func(c, than Code) bool { return true })
+	assert.False(t, ok)
+}
+
+func TestMultiError_Format(t *testing.T) {
+	var (
+		err1 = New(testCode(true), MD{K: "a", V: 1})
+		err2 = errors.New("some error")
+		err  = Join(err1, err2)
+		id   = err1.(derror).id
+	)
+
+	t.Run("code and message (%s)", func(t *testing.T) {
+		assert.Equal(t,
+			fmt.Sprintf("2 errors occurred: TestCode: an test code error has happened (id: %s); some error", id),
+			fmt.Sprintf("%s", err),
+		)
+		assert.Equal(t, fmt.Sprintf("%s", err), err.Error())
+	})
+
+	t.Run("code, message, id and metadata (%v)", func(t *testing.T) {
+		var exp = fmt.Sprintf("2 errors occurred:\n\t* %s\n\t* some error",
+			strings.Replace(fmt.Sprintf("%v", err1), "\n", "\n\t", -1),
+		)
+		assert.Equal(t, exp, fmt.Sprintf("%v", err))
+	})
+
+	t.Run("code, message, id, metadata and call stack (%+v)", func(t *testing.T) {
+		var s = fmt.Sprintf("%+v", err)
+		assert.Contains(t, s, strings.Replace(fmt.Sprintf("%+v", err1), "\n", "\n\t", -1))
+		assert.Contains(t, fmt.Sprintf("%+-v", err), "call stack (compacted)")
+	})
+
+	t.Run("any other verb", func(t *testing.T) {
+		assert.Empty(t, fmt.Sprintf("%d", err))
+	})
+}
+
+func TestMultiError_JSON(t *testing.T) {
+	var (
+		err1 = New(testCode(true), MD{K: "a", V: 1})
+		err  = fmt.Errorf("batch: %w", Join(err1, errors.New("some error")))
+	)
+
+	var b, je = ToJSON(err, false)
+	require.NoError(t, je)
+
+	var derr, perr = FromJSON(b, nil)
+	require.NoError(t, perr)
+	assert.Equal(t, err.Error(), derr.Error())
+	assert.True(t, Is(derr, testCode(true)))
+
+	var id, _ = GetID(derr)
+	assert.Equal(t, err1.(derror).id, id)
+
+	var errs = errors.Unwrap(derr).(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[1], "some error")
+}
+
+func TestMultiError_MarshalJSON(t *testing.T) {
+	var (
+		err1 = New(testCode(true), MD{K: "a", V: 1})
+		err  = Join(err1, errors.New("some error"))
+	)
+
+	var b, je = json.Marshal(err)
+	require.NoError(t, je)
+
+	var exp = fmt.Sprintf(`{
+		"message": %q,
+		"errors": [
+			{
+				"code": "TestCode",
+				"message": "an test code error has happened",
+				"id": %q,
+				"metadata": [{"key": "a", "value": 1}]
+			},
+			{"message": "some error"}
+		]
+	}`, err.Error(), err1.(derror).id)
+
+	assert.JSONEq(t, exp, string(b))
+}
@@ -1,25 +1,55 @@
 package errors
 
-import "github.com/gofrs/uuid"
-
-// Is return true if err is an error value created by one of the constructor
-// of this package and c has the same string representation (value returned by
-// the String method) and the same message (value returned by the  Message
-// method) to the error code, otherwise it returns false.
+// Is return true if the first error value, created by one of the constructor
+// of this package, found in the err chain has a Code with the same string
+// representation (value returned by the String method) and the same message
+// (value returned by the  Message method) to c, otherwise it returns false.
+// The err chain is composed by err itself followed by the errors obtained by
+// repeatedly calling its Unwrap method, which may return a single error or a
+// slice of them (see the standard errors package documentation); in the
+// latter case, the first error value of each of them is considered, so Is
+// returns true for an aggregate of errors (see Join) if any of them is c.
 func Is(err error, c Code) bool {
-	var derr, ok = err.(derror)
-	if !ok {
-		return false
-	}
+	var found bool
+	outermost(err, func(derr derror) bool {
+		found = equalCodes(derr.c, c)
+		return !found
+	})
 
-	return derr.c.String() == c.String() && derr.c.Message() == c.Message()
+	return found
 }
 
-// GetCode returns the Code of the err and true; if err isn't created by any of
-// the constructors of this package, false is returned and Code value can be
-// ignored.
+// IsAny returns true if any error value, created by one of the constructors of
+// this package, found in the err chain has the same Code than any of codes,
+// otherwise it returns false. Codes are compared with the same rule than Is.
+func IsAny(err error, codes ...Code) bool {
+	var found bool
+	walk(err, func(derr derror) bool {
+		for _, c := range codes {
+			if equalCodes(derr.c, c) {
+				found = true
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// HasCode returns true if any error value, created by one of the constructors
+// of this package, found in the err chain has the same Code than c, otherwise
+// it returns false. Codes are compared with the same rule than Is.
+func HasCode(err error, c Code) bool {
+	return IsAny(err, c)
+}
+
+// GetCode returns the Code of the first error value, created by one of the
+// constructors of this package, found in the err chain and true; if there isn't
+// any, false is returned and Code value can be ignored.
 func GetCode(err error) (Code, bool) {
-	var derr, ok = err.(derror)
+	var derr, ok = first(err)
 	if !ok {
 		return nil, false
 	}
@@ -27,13 +57,187 @@ func GetCode(err error) (Code, bool) {
 	return derr.c, true
 }
 
-// GetID returns the ID of the err and true; if err isn't created by any of the
-// constructors of this package, false is returned and ID value can be ignored.
-func GetID(err error) (uuid.UUID, bool) {
-	var derr, ok = err.(derror)
+// GetCodeFunc returns the Code of the error values, created by one of the
+// constructors of this package, found in the err chain which is chosen by
+// better and true; if there isn't any, false is returned and Code value can be
+// ignored.
+// better reports whether c is better than the current chosen Code, which is
+// the first one at the beginning. It's useful for choosing a Code among the
+// ones of an aggregate of errors (see Join), for example, the most severe.
+func GetCodeFunc(err error, better func(c, than Code) bool) (Code, bool) {
+	var (
+		bc    Code
+		found bool
+	)
+
+	walk(err, func(derr derror) bool {
+		if !found || better(derr.c, bc) {
+			bc = derr.c
+			found = true
+		}
+
+		return true
+	})
+
+	return bc, found
+}
+
+// GetID returns the ID of the first error value, created by one of the
+// constructors of this package, found in the err chain and true; if there isn't
+// any, false is returned and ID value can be ignored.
+func GetID(err error) (ID, bool) {
+	var derr, ok = first(err)
 	if !ok {
-		return uuid.UUID{}, false
+		return "", false
 	}
 
 	return derr.id, true
 }
+
+// first returns the first derror found in the err chain and true, otherwise it
+// returns false and derror value must be ignored.
+func first(err error) (derror, bool) {
+	var (
+		fderr derror
+		found bool
+	)
+
+	walk(err, func(derr derror) bool {
+		fderr = derr
+		found = true
+		return false
+	})
+
+	return fderr, found
+}
+
+// outermost traverses the err chain, in depth-first order, calling fn with
+// each derror found in it, without traversing the errors wrapped by them,
+// until fn returns false.
+// It returns false if fn returned false, otherwise true.
+func outermost(err error, fn func(derror) bool) bool {
+	for err != nil {
+		if derr, ok := err.(derror); ok {
+			return fn(derr)
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, ce := range e.Unwrap() {
+				if !outermost(ce, fn) {
+					return false
+				}
+			}
+
+			return true
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// walk traverses the err chain, in depth-first order, calling fn with each
+// derror found in it until fn returns false.
+// The chain is traversed through the Unwrap methods, which may return an error
+// or a slice of them. It returns false if fn returned false, otherwise true.
+func walk(err error, fn func(derror) bool) bool {
+	for err != nil {
+		if derr, ok := err.(derror); ok {
+			if !fn(derr) {
+				return false
+			}
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, ce := range e.Unwrap() {
+				if !walk(ce, fn) {
+					return false
+				}
+			}
+
+			return true
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// GetMD returns a copy of the metadata of the first error value, created by one
+// of the constructors of this package, found in the err chain; if there isn't
+// any or it doesn't have metadata, nil is returned.
+func GetMD(err error) []MD {
+	var derr, ok = first(err)
+	if !ok || len(derr.mds) == 0 {
+		return nil
+	}
+
+	var mds = make([]MD, len(derr.mds))
+	copy(mds, derr.mds)
+
+	return mds
+}
+
+// LookupMD returns the value of the metadata with key k and true, looking up
+// in all the error values, created by one of the constructors of this package,
+// found in the err chain; if there isn't any with such key, false is returned
+// and the value can be ignored.
+// When several metadata have the same key, the value is chosen with the same
+// rule than AllMD.
+func LookupMD(err error, k string) (interface{}, bool) {
+	var (
+		v     interface{}
+		found bool
+	)
+
+	walk(err, func(derr derror) bool {
+		for _, md := range derr.mds {
+			if md.K == k {
+				v = md.V
+				found = true
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return v, found
+}
+
+// AllMD returns the metadata of all the error values, created by one of the
+// constructors of this package, found in the err chain, merged in a single
+// slice; if there isn't any, nil is returned.
+// Metadata of the outer errors (the ones which wrap others) goes first and
+// when several metadata have the same key, only the first one is kept, so
+// outer errors win over the errors that they wrap and, in the same error, the
+// metadata passed first wins over the following ones.
+func AllMD(err error) []MD {
+	var (
+		mds  []MD
+		keys = map[string]struct{}{}
+	)
+
+	walk(err, func(derr derror) bool {
+		for _, md := range derr.mds {
+			if _, ok := keys[md.K]; ok {
+				continue
+			}
+
+			keys[md.K] = struct{}{}
+			mds = append(mds, md)
+		}
+
+		return true
+	})
+
+	return mds
+}
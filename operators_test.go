@@ -2,9 +2,9 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
-	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,6 +28,63 @@ func TestIs(t *testing.T) {
 		var err = errors.New("some error")
 		assert.False(t, Is(err, testCode(true)))
 	})
+
+	t.Run("wrapped by an external error", func(t *testing.T) {
+		var err = fmt.Errorf("some context: %w", New(testCode(true)))
+		assert.True(t, Is(err, testCode(true)))
+	})
+
+	t.Run("in a multi-error", func(t *testing.T) {
+		var err = errors.Join(errors.New("some error"), New(testCode(true)))
+		assert.True(t, Is(err, testCode(true)))
+	})
+
+	t.Run("only the first error of the chain", func(t *testing.T) {
+		var err = Wrap(New(testCode(true)), differentTestCode(true))
+		assert.False(t, Is(err, testCode(true)))
+	})
+}
+
+func TestIsAny(t *testing.T) {
+	var err = fmt.Errorf("some context: %w",
+		Wrap(New(differentTestCode(true)), testCode(true)),
+	)
+
+	t.Run("outermost error code", func(t *testing.T) {
+		assert.True(t, IsAny(err, testCode(true)))
+	})
+
+	t.Run("inner error code", func(t *testing.T) {
+		assert.True(t, IsAny(err, otherTestCode(true), differentTestCode(true)))
+	})
+
+	t.Run("in a multi-error", func(t *testing.T) {
+		var err = errors.Join(New(testCode(true)), New(differentTestCode(true)))
+		assert.True(t, IsAny(err, differentTestCode(true)))
+	})
+
+	t.Run("none of the error codes", func(t *testing.T) {
+		assert.False(t, IsAny(err, otherTestCode(true)))
+	})
+
+	t.Run("without error codes", func(t *testing.T) {
+		assert.False(t, IsAny(err))
+	})
+
+	t.Run("external error", func(t *testing.T) {
+		var err = errors.New("some error")
+		assert.False(t, IsAny(err, testCode(true)))
+	})
+}
+
+func TestHasCode(t *testing.T) {
+	var err = Wrap(fmt.Errorf("some context: %w", New(differentTestCode(true))), testCode(true))
+
+	assert.True(t, HasCode(err, testCode(true)))
+	assert.True(t, HasCode(err, similarTestCode(true)))
+	assert.True(t, HasCode(err, differentTestCode(true)))
+	assert.False(t, HasCode(err, otherTestCode(true)))
+	assert.False(t, HasCode(errors.New("some error"), testCode(true)))
 }
 
 func TestGetCode(t *testing.T) {
@@ -42,6 +99,17 @@ func TestGetCode(t *testing.T) {
 		assert.True(t, ok)
 	})
 
+	t.Run("wrapped by an external error", func(t *testing.T) {
+		var (
+			expc  = testCode(true)
+			err   = fmt.Errorf("some context: %w", Wrap(New(differentTestCode(true)), expc))
+			c, ok = GetCode(err)
+		)
+
+		assert.Equal(t, expc, c)
+		assert.True(t, ok)
+	})
+
 	t.Run("created by other package", func(t *testing.T) {
 		var (
 			err   = errors.New("some error")
@@ -59,7 +127,18 @@ func TestGetID(t *testing.T) {
 			id, ok = GetID(err)
 		)
 
-		assert.NotEqual(t, uuid.UUID{}, id)
+		assert.NotEmpty(t, id)
+		assert.True(t, ok)
+	})
+
+	t.Run("in a multi-error", func(t *testing.T) {
+		var (
+			derr   = New(testCode(true))
+			err    = errors.Join(errors.New("some error"), derr, New(testCode(true)))
+			id, ok = GetID(err)
+		)
+
+		assert.Equal(t, derr.(derror).id, id)
 		assert.True(t, ok)
 	})
 
@@ -98,3 +177,92 @@ func (differentTestCode) String() string {
 func (differentTestCode) Message() string {
 	return "This is a different error"
 }
+
+// otherTestCode is a silly example of a Code implementation with the only
+// purpose of testing the operators which check several codes.
+type otherTestCode bool
+
+func (otherTestCode) String() string {
+	return "OtherError"
+}
+
+func (otherTestCode) Message() string {
+	return "This is another error"
+}
+
+func TestGetMD(t *testing.T) {
+	t.Run("created by this package", func(t *testing.T) {
+		var (
+			err = Wrap(New(testCode(true), MD{K: "inner", V: 1}), testCode(true),
+				MD{K: "a", V: "va"}, MD{K: "b", V: 2},
+			)
+			mds = GetMD(err)
+		)
+
+		assert.Equal(t, []MD{{K: "a", V: "va"}, {K: "b", V: 2}}, mds)
+
+		// Make sure that the returned slice doesn't share the memory of the error
+		mds[0].V = "modified"
+		assert.Equal(t, []MD{{K: "a", V: "va"}, {K: "b", V: 2}}, GetMD(err))
+	})
+
+	t.Run("without metadata", func(t *testing.T) {
+		assert.Nil(t, GetMD(New(testCode(true))))
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		assert.Nil(t, GetMD(errors.New("some error")))
+	})
+}
+
+func TestLookupMD(t *testing.T) {
+	var err = Wrap(
+		fmt.Errorf("some context: %w",
+			New(testCode(true), MD{K: "a", V: "inner-a"}, MD{K: "c", V: "inner-c"}),
+		),
+		testCode(true),
+		MD{K: "a", V: "outer-a"}, MD{K: "b", V: "outer-b"}, MD{K: "b", V: "outer-b2"},
+	)
+
+	var tcases = []struct {
+		desc  string
+		key   string
+		expv  interface{}
+		expok bool
+	}{
+		{desc: "key in the outer error", key: "b", expv: "outer-b", expok: true},
+		{desc: "key in the inner error", key: "c", expv: "inner-c", expok: true},
+		{desc: "key in both errors", key: "a", expv: "outer-a", expok: true},
+		{desc: "key doesn't exist", key: "d", expv: nil, expok: false},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var v, ok = LookupMD(err, tc.key)
+			assert.Equal(t, tc.expv, v)
+			assert.Equal(t, tc.expok, ok)
+		})
+	}
+}
+
+func TestAllMD(t *testing.T) {
+	t.Run("created by this package", func(t *testing.T) {
+		var err = Wrap(
+			fmt.Errorf("some context: %w",
+				New(testCode(true), MD{K: "a", V: "inner-a"}, MD{K: "c", V: "inner-c"}),
+			),
+			testCode(true),
+			MD{K: "a", V: "outer-a"}, MD{K: "b", V: "outer-b"}, MD{K: "b", V: "outer-b2"},
+		)
+
+		assert.Equal(t,
+			[]MD{{K: "a", V: "outer-a"}, {K: "b", V: "outer-b"}, {K: "c", V: "inner-c"}},
+			AllMD(err),
+		)
+	})
+
+	t.Run("created by other package", func(t *testing.T) {
+		assert.Nil(t, AllMD(errors.New("some error")))
+	})
+}
@@ -0,0 +1,75 @@
+package errors
+
+import "fmt"
+
+// PanicMDKey is the key of the metadata which holds the recovered panic value
+// in the errors created by Recover.
+const PanicMDKey = "panic"
+
+// Recover recovers from a panic, if there is one, and sets to errp an error
+// with c and mds, replacing the error that errp points to.
+// It must be directly deferred, otherwise it cannot recover the panic, and
+// errp must not be nil.
+//
+//	func do() (err error) {
+//		defer errors.Recover(CodePanic, &err)
+//		...
+//	}
+//
+// The error holds the panic value in the metadata with the PanicMDKey key and
+// its call stack is the one of the panicking goroutine from the point where it
+// panicked. When the panic value is an error, including the runtime errors
+// (see runtime.Error), the error wraps it, otherwise it wraps an error whose
+// message is the panic value formatted with the 'v' verb.
+func Recover(c Code, errp *error, mds ...MD) {
+	var v = recover()
+	if v == nil {
+		return
+	}
+
+	*errp = newPanicError(c, v, mds)
+}
+
+// Go calls fn in a new goroutine, recovering from its panics as Recover does
+// with c and mds, and sends the error returned by fn, or the one created from
+// the panic, to the returned channel, which is closed afterwards.
+func Go(c Code, fn func() error, mds ...MD) <-chan error {
+	var errc = make(chan error, 1)
+	go func() {
+		defer close(errc)
+
+		var err error
+		func() {
+			defer Recover(c, &err, mds...)
+			err = fn()
+		}()
+
+		errc <- err
+	}()
+
+	return errc
+}
+
+func newPanicError(c Code, v interface{}, mds []MD) error {
+	var werr, ok = v.(error)
+	if !ok {
+		werr = panicError{v: v}
+	}
+
+	var pmds = make([]MD, len(mds), len(mds)+1)
+	copy(pmds, mds)
+	pmds = append(pmds, MD{K: PanicMDKey, V: v})
+
+	return wrap(werr, newDerror(c, pmds, newPanicCallStack()))
+}
+
+// panicError is the error wrapped by the errors created from a recovered panic
+// whose value isn't an error.
+type panicError struct {
+	v interface{}
+}
+
+// Error satisfies the standard error interface.
+func (err panicError) Error() string {
+	return fmt.Sprintf("panic: %v", err.v)
+}
@@ -0,0 +1,174 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	var tcases = []struct {
+		desc     string
+		fn       func() error
+		expPanic func(t *testing.T, v interface{})
+		expWerr  func(t *testing.T, werr error)
+	}{
+		{
+			desc: "string value",
+			fn:   panicString,
+			expPanic: func(t *testing.T, v interface{}) {
+				assert.Equal(t, "boom", v)
+			},
+			expWerr: func(t *testing.T, werr error) {
+				assert.EqualError(t, werr, "panic: boom")
+			},
+		},
+		{
+			desc: "error value",
+			fn:   panicErrorValue,
+			expPanic: func(t *testing.T, v interface{}) {
+				assert.Equal(t, errPanic, v)
+			},
+			expWerr: func(t *testing.T, werr error) {
+				assert.Equal(t, errPanic, werr)
+			},
+		},
+		{
+			desc: "runtime error",
+			fn:   panicRuntime,
+			expPanic: func(t *testing.T, v interface{}) {
+				assert.Implements(t, (*runtime.Error)(nil), v)
+			},
+			expWerr: func(t *testing.T, werr error) {
+				var rerr runtime.Error
+				assert.True(t, errors.As(werr, &rerr))
+			},
+		},
+		{
+			desc: "nil map",
+			fn:   panicNilMap,
+			expPanic: func(t *testing.T, v interface{}) {
+				assert.Implements(t, (*runtime.Error)(nil), v)
+			},
+			expWerr: func(t *testing.T, werr error) {
+				var rerr runtime.Error
+				assert.True(t, errors.As(werr, &rerr))
+			},
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var err = recoverCall(tc.fn, MD{K: "var1", V: "a string"})
+			require.Error(t, err)
+
+			assert.True(t, Is(err, testCode(true)))
+
+			var v, ok = LookupMD(err, PanicMDKey)
+			require.True(t, ok)
+			tc.expPanic(t, v)
+
+			v, _ = LookupMD(err, "var1")
+			assert.Equal(t, "a string", v)
+
+			tc.expWerr(t, errors.Unwrap(err))
+
+			// The first frame is the function which panicked.
+			var frs = StackTrace(err)
+			require.NotEmpty(t, frs)
+			assert.True(t,
+				strings.HasPrefix(frs[0].Function, "go.fraixed.es/errors.panic"), "function: %s", frs[0].Function,
+			)
+		})
+	}
+
+	t.Run("no panic", func(t *testing.T) {
+		var err = recoverCall(func() error { return nil })
+		assert.NoError(t, err)
+
+		var e = fmt.Errorf("some error")
+		err = recoverCall(func() error { return e })
+		assert.Equal(t, e, err)
+	})
+
+	t.Run("call stack disabled", func(t *testing.T) {
+		defer pkgConfig.Store(getConfig())
+		Configure(WithCallStack(false))
+
+		var err = recoverCall(panicString)
+		assert.True(t, Is(err, testCode(true)))
+		assert.Empty(t, StackTrace(err))
+	})
+
+	t.Run("call stack max depth", func(t *testing.T) {
+		defer pkgConfig.Store(getConfig())
+		Configure(WithCallStackMaxDepth(2))
+
+		var (
+			err = recoverCall(panicString)
+			frs = StackTrace(err)
+		)
+		require.Len(t, frs, 2)
+		assert.Equal(t, "go.fraixed.es/errors.panicString", frs[0].Function)
+	})
+}
+
+func TestGo(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		var e = fmt.Errorf("some error")
+		assert.Equal(t, e, <-Go(testCode(true), func() error { return e }))
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		var errc = Go(testCode(true), func() error { return nil })
+		assert.NoError(t, <-errc)
+
+		var _, ok = <-errc
+		assert.False(t, ok)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var err = <-Go(testCode(true), panicString, MD{K: "var1", V: "a string"})
+		assert.True(t, Is(err, testCode(true)))
+
+		var v, _ = LookupMD(err, PanicMDKey)
+		assert.Equal(t, "boom", v)
+
+		var frs = StackTrace(err)
+		require.NotEmpty(t, frs)
+		assert.Equal(t, "go.fraixed.es/errors.panicString", frs[0].Function)
+	})
+}
+
+var errPanic = errors.New("panic error")
+
+func recoverCall(fn func() error, mds ...MD) (err error) {
+	defer Recover(testCode(true), &err, mds...)
+	return fn()
+}
+
+func panicString() error {
+	panic("boom")
+}
+
+func panicErrorValue() error {
+	panic(errPanic)
+}
+
+func panicRuntime() error {
+	var s []int
+	var i = 3
+	return fmt.Errorf("%d", s[i])
+}
+
+func panicNilMap() error {
+	var m map[string]int
+	m["a"] = 1
+	return nil
+}
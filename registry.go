@@ -0,0 +1,253 @@
+package errors
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry is a set of Codes identified by their string representation (value
+// returned by the String method), hence it guarantees that there aren't two
+// different Codes with the same string representation.
+//
+// It allows to obtain the Code from its string representation, which is useful
+// for reconstructing the errors which are transmitted over the wire, and to
+// list all the Codes, which is useful for documenting them.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	codes    map[string]Code
+	statuses map[string]int
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		codes:    map[string]Code{},
+		statuses: map[string]int{},
+	}
+}
+
+// Register adds cs to the registry.
+// It returns an error with the ErrCodeDuplicated Code if any of cs has the same
+// string representation than an already registered Code or than another Code
+// of cs, and an error with the ErrCodeMalformedTemplate Code if the message
+// template of any of cs, which satisfies the MessageTemplater interface, isn't
+// well formed (see ValidateMessage); in such cases none of cs is registered.
+func (r *Registry) Register(cs ...Code) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ncs = make(map[string]struct{}, len(cs))
+	for _, c := range cs {
+		var s = c.String()
+		if _, ok := r.codes[s]; ok {
+			return New(ErrCodeDuplicated, MD{K: "code", V: s})
+		}
+
+		if _, ok := ncs[s]; ok {
+			return New(ErrCodeDuplicated, MD{K: "code", V: s})
+		}
+
+		if mt, ok := c.(MessageTemplater); ok {
+			if err := ValidateMessage(mt.MessageTemplate()); err != nil {
+				return Wrap(err, ErrCodeMalformedTemplate, MD{K: "code", V: s})
+			}
+		}
+
+		ncs[s] = struct{}{}
+	}
+
+	for _, c := range cs {
+		r.codes[c.String()] = c
+	}
+
+	return nil
+}
+
+// MustRegister is like Register but it panics if any of cs cannot be
+// registered. It's meant to be used in the init functions of the packages
+// which define Codes.
+func (r *Registry) MustRegister(cs ...Code) {
+	if err := r.Register(cs...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the registered Code whose string representation is s and
+// true; if there isn't any, false is returned and the Code can be ignored.
+func (r *Registry) Lookup(s string) (Code, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var c, ok = r.codes[s]
+	return c, ok
+}
+
+// Resolve returns the registered Code whose string representation is s; if
+// there isn't any, it returns a Code whose String and Message methods return s
+// and msg respectively, so the errors which are transmitted over the wire can
+// be reconstructed even when their Codes aren't registered.
+func (r *Registry) Resolve(s, msg string) Code {
+	if c, ok := r.Lookup(s); ok {
+		return c
+	}
+
+	return remoteCode{s: s, msg: msg}
+}
+
+// Codes returns all the registered Codes sorted by their string
+// representation.
+func (r *Registry) Codes() []Code {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cs = make([]Code, 0, len(r.codes))
+	for _, c := range r.codes {
+		cs = append(cs, c)
+	}
+
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].String() < cs[j].String()
+	})
+
+	return cs
+}
+
+// Parent returns the Code which c is under and true; if c isn't under any Code,
+// false is returned and the Code can be ignored.
+// The parent is the one returned by the Parent method of c, when it satisfies
+// the Parenter interface, otherwise the registered Code whose string
+// representation is the namespace of the one of c (see NamespaceSeparator) or,
+// if there isn't any registered, the Namespace.
+func (r *Registry) Parent(c Code) (Code, bool) {
+	if p, ok := c.(Parenter); ok {
+		var pc = p.Parent()
+		return pc, pc != nil
+	}
+
+	var ns, ok = namespaceOf(c.String())
+	if !ok {
+		return nil, false
+	}
+
+	if pc, ok := r.Lookup(ns); ok {
+		return pc, true
+	}
+
+	return Namespace(ns), true
+}
+
+// Node is a Code of the hierarchy of the registered Codes (see Registry.Tree)
+// with the Codes which are under it.
+type Node struct {
+	Code     Code
+	Children []Node
+}
+
+// Tree returns the hierarchy of the registered Codes, which are under the
+// Codes returned by the Parent method, sorted by their string representation.
+// The returned nodes are the Codes which aren't under any other, and the
+// parents which aren't registered are included too.
+func (r *Registry) Tree() []Node {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	var (
+		codes    = map[string]Code{}
+		children = map[string][]string{}
+		state    = map[string]int{}
+		roots    []string
+		visit    func(c Code, depth int)
+	)
+
+	visit = func(c Code, depth int) {
+		var s = c.String()
+		if state[s] != 0 {
+			return
+		}
+
+		state[s] = visiting
+		codes[s] = c
+
+		// A Code whose ancestors are too many or include itself is considered a
+		// root to avoid infinite loops.
+		var p, ok = r.Parent(c)
+		if ok && depth < maxAncestors && state[p.String()] != visiting {
+			visit(p, depth+1)
+			children[p.String()] = append(children[p.String()], s)
+		} else {
+			roots = append(roots, s)
+		}
+
+		state[s] = visited
+	}
+
+	for _, c := range r.Codes() {
+		visit(c, 0)
+	}
+
+	var nodes func(ss []string) []Node
+	nodes = func(ss []string) []Node {
+		if len(ss) == 0 {
+			return nil
+		}
+
+		sort.Strings(ss)
+		var ns = make([]Node, 0, len(ss))
+		for _, s := range ss {
+			ns = append(ns, Node{Code: codes[s], Children: nodes(children[s])})
+		}
+
+		return ns
+	}
+
+	return nodes(roots)
+}
+
+// defaultRegistry is the process-wide Registry used by the package functions
+// which deal with registered Codes.
+var defaultRegistry = NewRegistry()
+
+func init() {
+	defaultRegistry.MustRegister(
+		ErrCodeDuplicated, ErrCodeMalformedJSON, ErrCodeMalformedTemplate, ErrCodeMissingTemplateKey,
+	)
+}
+
+// DefaultRegistry returns the process-wide Registry, which is the one used by
+// the package functions Register, MustRegister, Lookup, Codes and Tree.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds cs to the default registry. See Registry.Register.
+func Register(cs ...Code) error {
+	return defaultRegistry.Register(cs...)
+}
+
+// MustRegister adds cs to the default registry and panics if any of them
+// cannot be registered. See Registry.MustRegister.
+func MustRegister(cs ...Code) {
+	defaultRegistry.MustRegister(cs...)
+}
+
+// Lookup returns the Code registered in the default registry whose string
+// representation is s. See Registry.Lookup.
+func Lookup(s string) (Code, bool) {
+	return defaultRegistry.Lookup(s)
+}
+
+// Codes returns all the Codes registered in the default registry. See
+// Registry.Codes.
+func Codes() []Code {
+	return defaultRegistry.Codes()
+}
+
+// Tree returns the hierarchy of the Codes registered in the default registry.
+// See Registry.Tree.
+func Tree() []Node {
+	return defaultRegistry.Tree()
+}
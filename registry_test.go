@@ -0,0 +1,162 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	t.Run("codes with different string representations", func(t *testing.T) {
+		var r = NewRegistry()
+		require.NoError(t, r.Register(testCode(true), differentTestCode(true)))
+		require.NoError(t, r.Register(otherTestCode(true)))
+
+		assert.Equal(t, []Code{differentTestCode(true), otherTestCode(true), testCode(true)}, r.Codes())
+	})
+
+	t.Run("code already registered", func(t *testing.T) {
+		var r = NewRegistry()
+		require.NoError(t, r.Register(testCode(true)))
+
+		var err = r.Register(otherTestCode(true), similarTestCode(true))
+		require.Error(t, err)
+		assert.True(t, Is(err, ErrCodeDuplicated))
+
+		var v, _ = LookupMD(err, "code")
+		assert.Equal(t, testCode(true).String(), v)
+
+		// None of the codes must be registered
+		assert.Equal(t, []Code{testCode(true)}, r.Codes())
+	})
+
+	t.Run("duplicated codes in the same call", func(t *testing.T) {
+		var (
+			r   = NewRegistry()
+			err = r.Register(testCode(true), otherTestCode(true), similarTestCode(false))
+		)
+
+		assert.True(t, Is(err, ErrCodeDuplicated))
+		assert.Empty(t, r.Codes())
+	})
+}
+
+func TestRegistry_MustRegister(t *testing.T) {
+	var r = NewRegistry()
+	assert.NotPanics(t, func() { r.MustRegister(testCode(true)) })
+	assert.Panics(t, func() { r.MustRegister(similarTestCode(true)) })
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	var r = NewRegistry()
+	r.MustRegister(testCode(true), differentTestCode(true))
+
+	t.Run("registered code", func(t *testing.T) {
+		var c, ok = r.Lookup(differentTestCode(true).String())
+		assert.True(t, ok)
+		assert.Equal(t, differentTestCode(true), c)
+	})
+
+	t.Run("not registered code", func(t *testing.T) {
+		var _, ok = r.Lookup(otherTestCode(true).String())
+		assert.False(t, ok)
+	})
+}
+
+func TestRegistry_Parent(t *testing.T) {
+	var (
+		r  = NewRegistry()
+		db = parentedTestCode{s: "db"}
+	)
+	r.MustRegister(hierarchyTestCode("storage"))
+
+	var tcases = []struct {
+		desc string
+		code Code
+		exp  Code
+	}{
+		{
+			desc: "registered namespace",
+			code: hierarchyTestCode("storage.NotFound"),
+			exp:  hierarchyTestCode("storage"),
+		},
+		{
+			desc: "not registered namespace",
+			code: hierarchyTestCode("storage.sql.Conflict"),
+			exp:  Namespace("storage.sql"),
+		},
+		{
+			desc: "parent method",
+			code: parentedTestCode{s: "storage.Timeout", parent: db},
+			exp:  db,
+		},
+		{
+			desc: "without namespace",
+			code: hierarchyTestCode("storage"),
+		},
+		{
+			desc: "empty namespace",
+			code: hierarchyTestCode(".NotFound"),
+		},
+		{
+			desc: "parent method returning nil",
+			code: db,
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var c, ok = r.Parent(tc.code)
+			assert.Equal(t, tc.exp != nil, ok)
+			assert.Equal(t, tc.exp, c)
+		})
+	}
+}
+
+func TestRegistry_Tree(t *testing.T) {
+	var (
+		r    = NewRegistry()
+		db   = parentedTestCode{s: "db"}
+		conn = parentedTestCode{s: "db.conn.Closed", parent: db}
+	)
+	r.MustRegister(
+		hierarchyTestCode("storage.sql.Conflict"),
+		hierarchyTestCode("storage.NotFound"),
+		hierarchyTestCode("storage"),
+		hierarchyTestCode("Unexpected"),
+		conn,
+		selfParentTestCode(true),
+	)
+
+	assert.Equal(t, []Node{
+		{Code: selfParentTestCode(true)},
+		{Code: hierarchyTestCode("Unexpected")},
+		{Code: db, Children: []Node{{Code: conn}}},
+		{Code: hierarchyTestCode("storage"), Children: []Node{
+			{Code: hierarchyTestCode("storage.NotFound")},
+			{Code: Namespace("storage.sql"), Children: []Node{
+				{Code: hierarchyTestCode("storage.sql.Conflict")},
+			}},
+		}},
+	}, r.Tree())
+
+	assert.Empty(t, NewRegistry().Tree())
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	var c, ok = Lookup(ErrCodeDuplicated.String())
+	assert.True(t, ok)
+	assert.Equal(t, ErrCodeDuplicated, c)
+	assert.Contains(t, Codes(), Code(ErrCodeDuplicated))
+	assert.Error(t, Register(ErrCodeDuplicated))
+	assert.Panics(t, func() { MustRegister(ErrCodeDuplicated) })
+
+	var ns []Node
+	for _, n := range Tree() {
+		if n.Code.String() == "errors" {
+			ns = n.Children
+		}
+	}
+	assert.Contains(t, ns, Node{Code: ErrCodeDuplicated})
+}
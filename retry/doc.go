@@ -0,0 +1,22 @@
+/*
+Package retry runs operations retrying them, with exponential backoff and
+jitter, while they fail with errors whose Codes are retryable.
+
+The Codes declare that the errors identified by them are retryable satisfying
+the Retryabler or the RetryAfterer interfaces; the errors which don't have a
+Code which satisfies any of them aren't retried.
+
+	func (c Code) Retryable() bool {
+		return c == CodeUnavailable || c == CodeTimeout
+	}
+
+	err := retry.Do(ctx, CodeFetchFailed, func(ctx context.Context) error {
+		return fetch(ctx, url)
+	})
+
+When the operation doesn't succeed, the returned error has the Code passed to
+Do and it wraps the error of the last attempt, having the number of attempts
+and the IDs of the errors of all the attempts as metadata (see AttemptsMDKey
+and AttemptIDsMDKey).
+*/
+package retry
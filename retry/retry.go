@@ -0,0 +1,213 @@
+package retry
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"go.fraixed.es/errors"
+)
+
+const (
+	// AttemptsMDKey is the key of the metadata which holds the number of
+	// attempts in the errors returned by Do.
+	AttemptsMDKey = "attempts"
+	// AttemptIDsMDKey is the key of the metadata which holds the IDs of the
+	// errors of the attempts, in order, in the errors returned by Do. The
+	// errors which don't have ID are omitted.
+	AttemptIDsMDKey = "attempt_ids"
+)
+
+// Retryabler is the interface that the Codes can optionally satisfy for
+// indicating if the errors identified by them are retryable.
+type Retryabler interface {
+	Retryable() bool
+}
+
+// RetryAfterer is the interface that the Codes can optionally satisfy for
+// indicating that the errors identified by them are retryable after some time,
+// which is used instead of the backoff delay; a duration lower than 1 means
+// that they aren't retryable.
+type RetryAfterer interface {
+	RetryAfter() time.Duration
+}
+
+// Clock provides the time to a Policy.
+type Clock interface {
+	// After waits for d to elapse and then sends the current time on the
+	// returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock of the real time.
+type realClock struct{}
+
+// After satisfies the Clock interface.
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Policy defines how an operation is retried. The zero value is a valid policy
+// which uses the default values of its fields.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// It's 3 by default.
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry. It's 100ms by default.
+	InitialDelay time.Duration
+	// MaxDelay is the maximum delay between attempts, excluding the jitter.
+	// It's 10s by default.
+	MaxDelay time.Duration
+	// Multiplier is the factor which multiplies the delay after each retry.
+	// It's 2 by default.
+	Multiplier float64
+	// Jitter is the fraction of the delay, between 0 and 1, which is randomly
+	// added or subtracted to it. It's 0.2 by default and a negative value
+	// disables it.
+	Jitter float64
+	// Clock provides the time. It's the real time by default.
+	Clock Clock
+	// Rand returns random numbers in [0, 1) for the jitter. It's
+	// math/rand.Float64 by default.
+	Rand func() float64
+}
+
+// DefaultPolicy is the Policy used by Do.
+var DefaultPolicy = Policy{}
+
+// Do calls op, retrying it with DefaultPolicy. See Policy.Do.
+func Do(ctx context.Context, c errors.Code, op func(ctx context.Context) error, mds ...errors.MD) error {
+	return DefaultPolicy.Do(ctx, c, op, mds...)
+}
+
+// Do calls op until it succeeds, it fails with an error which isn't
+// retryable, the maximum number of attempts is reached or ctx is done.
+//
+// An error is retryable if it, or any error of its chain, has a Code which
+// satisfies Retryabler and it returns true or it satisfies RetryAfterer and it
+// returns a positive duration; the Code of the outermost error, which satisfies
+// any of them, is the one which decides.
+//
+// It returns nil if op succeeds, otherwise an error with c and mds wrapping
+// the error of the last attempt, or the aggregate of it and the ctx error (see
+// errors.Join) when ctx is done, with the metadata described by AttemptsMDKey
+// and AttemptIDsMDKey.
+func (p Policy) Do(ctx context.Context, c errors.Code, op func(ctx context.Context) error, mds ...errors.MD) error {
+	p = p.withDefaults()
+
+	var (
+		ids   []errors.ID
+		delay = p.InitialDelay
+	)
+
+	for attempt := 1; ; attempt++ {
+		var err = op(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if id, ok := errors.GetID(err); ok {
+			ids = append(ids, id)
+		}
+
+		var retryable, after = isRetryable(err)
+		if !retryable || attempt >= p.MaxAttempts {
+			return p.fail(err, c, attempt, ids, mds)
+		}
+
+		var wait = after
+		if wait <= 0 {
+			wait = p.jitter(delay)
+			delay = time.Duration(math.Min(float64(delay)*p.Multiplier, float64(p.MaxDelay)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return p.fail(errors.Join(err, ctx.Err()), c, attempt, ids, mds)
+		case <-p.Clock.After(wait):
+		}
+	}
+}
+
+// withDefaults returns p with the default values set to its zero value fields.
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = 100 * time.Millisecond
+	}
+
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 10 * time.Second
+	}
+
+	if p.Multiplier <= 0 {
+		p.Multiplier = 2
+	}
+
+	if p.Jitter == 0 {
+		p.Jitter = 0.2
+	} else if p.Jitter < 0 {
+		p.Jitter = 0
+	} else if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+
+	if p.Rand == nil {
+		p.Rand = rand.Float64
+	}
+
+	return p
+}
+
+// jitter returns d with a random fraction of it, up to p.Jitter, added or
+// subtracted.
+func (p Policy) jitter(d time.Duration) time.Duration {
+	if p.Jitter == 0 {
+		return d
+	}
+
+	return d + time.Duration((2*p.Rand()-1)*p.Jitter*float64(d))
+}
+
+// fail returns the error of an operation which has failed after attempts.
+func (p Policy) fail(err error, c errors.Code, attempts int, ids []errors.ID, mds []errors.MD) error {
+	var fmds = make([]errors.MD, 0, len(mds)+2)
+	fmds = append(fmds,
+		errors.MD{K: AttemptsMDKey, V: attempts},
+		errors.MD{K: AttemptIDsMDKey, V: ids},
+	)
+	fmds = append(fmds, mds...)
+
+	return errors.WrapSkip(2, err, c, fmds...)
+}
+
+// isRetryable returns true if err is retryable and the time to wait before
+// retrying it when its Code satisfies RetryAfterer.
+func isRetryable(err error) (bool, time.Duration) {
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		var c, ok = errors.GetCode(err)
+		if !ok {
+			return false, 0
+		}
+
+		if ra, ok := c.(RetryAfterer); ok {
+			var d = ra.RetryAfter()
+			return d > 0, d
+		}
+
+		if r, ok := c.(Retryabler); ok {
+			return r.Retryable(), 0
+		}
+	}
+
+	return false, 0
+}
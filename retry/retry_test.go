@@ -0,0 +1,181 @@
+package retry
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+)
+
+func TestPolicy_Do(t *testing.T) {
+	t.Run("succeeds after retries", func(t *testing.T) {
+		var (
+			clock = &fakeClock{}
+			p     = Policy{MaxAttempts: 5, Clock: clock, Jitter: -1}
+			calls int
+		)
+
+		var err = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+			calls++
+			if calls < 4 {
+				return errors.New(retryableTestCode(true))
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, calls)
+		assert.Equal(t,
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+			clock.waits,
+		)
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		var (
+			clock = &fakeClock{}
+			p     = Policy{Clock: clock, Jitter: -1}
+			ids   []errors.ID
+		)
+
+		var err = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+			var err = fmt.Errorf("wrapped: %w", errors.New(retryableTestCode(true)))
+			var id, _ = errors.GetID(err)
+			ids = append(ids, id)
+			return err
+		}, errors.MD{K: "url", V: "http://example.com"})
+		require.Error(t, err)
+		assert.Len(t, clock.waits, 2)
+
+		var c, _ = errors.GetCode(err)
+		assert.Equal(t, doTestCode(true).String(), c.String())
+		assert.True(t, errors.HasCode(err, retryableTestCode(true)))
+
+		var v, _ = errors.LookupMD(err, AttemptsMDKey)
+		assert.Equal(t, 3, v)
+		v, _ = errors.LookupMD(err, AttemptIDsMDKey)
+		assert.Equal(t, ids, v)
+		v, _ = errors.LookupMD(err, "url")
+		assert.Equal(t, "http://example.com", v)
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		var (
+			clock = &fakeClock{}
+			p     = Policy{Clock: clock}
+			calls int
+		)
+
+		var tcases = []struct {
+			desc string
+			err  error
+		}{
+			{desc: "code isn't retryable", err: errors.New(retryableTestCode(false))},
+			{desc: "code without retry interfaces", err: errors.New(doTestCode(true))},
+			{desc: "error without code", err: fmt.Errorf("some error")},
+			{desc: "retry after isn't positive", err: errors.New(retryAfterTestCode(0))},
+		}
+
+		for i := range tcases {
+			var tc = tcases[i]
+			t.Run(tc.desc, func(t *testing.T) {
+				calls = 0
+				var err = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+					calls++
+					return tc.err
+				})
+				assert.Equal(t, 1, calls)
+				assert.True(t, stderrors.Is(err, tc.err))
+
+				var v, _ = errors.LookupMD(err, AttemptsMDKey)
+				assert.Equal(t, 1, v)
+			})
+		}
+
+		assert.Empty(t, clock.waits)
+	})
+
+	t.Run("outermost retry code decides", func(t *testing.T) {
+		var (
+			p     = Policy{Clock: &fakeClock{}}
+			calls int
+		)
+
+		var _ = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+			calls++
+			return errors.Wrap(errors.New(retryableTestCode(true)), retryableTestCode(false))
+		})
+		assert.Equal(t, 1, calls)
+
+		calls = 0
+		var _ = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+			calls++
+			return errors.Wrap(errors.New(retryableTestCode(false)), doTestCode(true))
+		})
+		assert.Equal(t, 1, calls, "inner code isn't retryable")
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		var (
+			clock = &fakeClock{}
+			p     = Policy{Clock: clock}
+		)
+
+		var _ = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+			return errors.New(retryAfterTestCode(3 * time.Second))
+		})
+		assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second}, clock.waits)
+	})
+
+	t.Run("max delay and jitter", func(t *testing.T) {
+		var (
+			clock = &fakeClock{}
+			p     = Policy{
+				MaxAttempts:  5,
+				InitialDelay: time.Second,
+				MaxDelay:     3 * time.Second,
+				Multiplier:   3,
+				Jitter:       0.5,
+				Clock:        clock,
+				Rand:         func() float64 { return 1 },
+			}
+		)
+
+		var _ = p.Do(context.Background(), doTestCode(true), func(context.Context) error {
+			return errors.New(retryableTestCode(true))
+		})
+		assert.Equal(t,
+			[]time.Duration{1500 * time.Millisecond, 4500 * time.Millisecond, 4500 * time.Millisecond, 4500 * time.Millisecond},
+			clock.waits,
+		)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			p           = Policy{Clock: blockingClock{}}
+			calls       int
+		)
+
+		var err = p.Do(ctx, doTestCode(true), func(context.Context) error {
+			calls++
+			cancel()
+			return errors.New(retryableTestCode(true))
+		})
+		assert.Equal(t, 1, calls)
+		assert.True(t, stderrors.Is(err, context.Canceled))
+		assert.True(t, errors.HasCode(err, retryableTestCode(true)))
+	})
+}
+
+func TestDo(t *testing.T) {
+	var err = Do(context.Background(), doTestCode(true), func(context.Context) error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
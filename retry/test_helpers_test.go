@@ -0,0 +1,68 @@
+package retry
+
+import "time"
+
+// retryableTestCode is a silly example of a Code implementation with the only
+// purpose of testing this package; its value indicates if it's retryable.
+type retryableTestCode bool
+
+func (retryableTestCode) String() string {
+	return "RetryableTestCode"
+}
+
+func (retryableTestCode) Message() string {
+	return "the test operation has failed"
+}
+
+func (c retryableTestCode) Retryable() bool {
+	return bool(c)
+}
+
+// retryAfterTestCode is a silly example of a Code implementation with the only
+// purpose of testing this package; its value is the time to wait before
+// retrying.
+type retryAfterTestCode time.Duration
+
+func (retryAfterTestCode) String() string {
+	return "RetryAfterTestCode"
+}
+
+func (retryAfterTestCode) Message() string {
+	return "the test operation has been throttled"
+}
+
+func (c retryAfterTestCode) RetryAfter() time.Duration {
+	return time.Duration(c)
+}
+
+// doTestCode is a silly example of a Code implementation with the only purpose
+// of testing this package.
+type doTestCode bool
+
+func (doTestCode) String() string {
+	return "DoTestCode"
+}
+
+func (doTestCode) Message() string {
+	return "the test operation couldn't be done"
+}
+
+// fakeClock is a Clock which doesn't wait and records the waited durations.
+type fakeClock struct {
+	waits []time.Duration
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+
+	var ch = make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+// blockingClock is a Clock which never ends waiting.
+type blockingClock struct{}
+
+func (blockingClock) After(time.Duration) <-chan time.Time {
+	return nil
+}
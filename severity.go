@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// SeverityLevel is the severity of the errors identified by a Code.
+type SeverityLevel uint8
+
+const (
+	// SeverityDebug is for errors which are only relevant when debugging.
+	SeverityDebug SeverityLevel = iota + 1
+	// SeverityInfo is for errors which are part of the normal operation, for
+	// example, invalid user input.
+	SeverityInfo
+	// SeverityWarn is for errors which may require attention if they happen
+	// frequently.
+	SeverityWarn
+	// SeverityError is for errors which require attention.
+	SeverityError
+	// SeverityFatal is for errors which require immediate attention because
+	// the system cannot operate.
+	SeverityFatal
+)
+
+// String returns the name of the severity level.
+func (s SeverityLevel) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return fmt.Sprintf("SeverityLevel(%d)", uint8(s))
+	}
+}
+
+// Level returns the slog level which corresponds to s; SeverityFatal is 4
+// levels above slog.LevelError and an unknown severity level is
+// slog.LevelError.
+func (s SeverityLevel) Level() slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarn:
+		return slog.LevelWarn
+	case SeverityFatal:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
+// Severitier is the interface that the Codes can optionally satisfy for
+// indicating the severity of the errors identified by them.
+// Severity returns 0 when the Code doesn't have any, so it's considered as if
+// it didn't satisfy this interface.
+type Severitier interface {
+	Severity() SeverityLevel
+}
+
+// CategoryKind is the category of the errors identified by a Code, which
+// indicates who is responsible of them.
+type CategoryKind uint8
+
+const (
+	// CategoryClient is for errors caused by the clients, for example, invalid
+	// requests.
+	CategoryClient CategoryKind = iota + 1
+	// CategoryServer is for errors caused by the system itself, for example,
+	// bugs.
+	CategoryServer
+	// CategoryDependency is for errors caused by the dependencies of the
+	// system, for example, a database which isn't available.
+	CategoryDependency
+	// CategorySecurity is for errors related with the security, for example,
+	// authentication failures.
+	CategorySecurity
+)
+
+// String returns the name of the category.
+func (c CategoryKind) String() string {
+	switch c {
+	case CategoryClient:
+		return "client"
+	case CategoryServer:
+		return "server"
+	case CategoryDependency:
+		return "dependency"
+	case CategorySecurity:
+		return "security"
+	default:
+		return fmt.Sprintf("CategoryKind(%d)", uint8(c))
+	}
+}
+
+// Categorizer is the interface that the Codes can optionally satisfy for
+// indicating the category of the errors identified by them.
+// Category returns 0 when the Code doesn't have any, so it's considered as if
+// it didn't satisfy this interface.
+type Categorizer interface {
+	Category() CategoryKind
+}
+
+// Severity returns the severity level of the first error value, created by one
+// of the constructors of this package, found in the err chain whose Code
+// satisfies the Severitier interface, with a severity level other than 0, and
+// true; if there isn't any, false is returned and the severity level can be
+// ignored.
+func Severity(err error) (SeverityLevel, bool) {
+	var (
+		sl    SeverityLevel
+		found bool
+	)
+
+	walk(err, func(derr derror) bool {
+		sl = codeSeverity(derr.c)
+		found = sl != 0
+		return !found
+	})
+
+	return sl, found
+}
+
+// Category returns the category of the first error value, created by one of
+// the constructors of this package, found in the err chain whose Code
+// satisfies the Categorizer interface, with a category other than 0, and true;
+// if there isn't any, false is returned and the category can be ignored.
+func Category(err error) (CategoryKind, bool) {
+	var (
+		ck    CategoryKind
+		found bool
+	)
+
+	walk(err, func(derr derror) bool {
+		ck = codeCategory(derr.c)
+		found = ck != 0
+		return !found
+	})
+
+	return ck, found
+}
+
+// MoreSevere reports whether c is more severe than than; the Codes which don't
+// satisfy the Severitier interface, or whose severity level is 0, are less
+// severe than any other. It's meant to be used with GetCodeFunc for choosing
+// the most severe Code.
+func MoreSevere(c, than Code) bool {
+	return codeSeverity(c) > codeSeverity(than)
+}
+
+// codeSeverity returns the severity level of c or 0 if it doesn't satisfy the
+// Severitier interface or it doesn't have any.
+func codeSeverity(c Code) SeverityLevel {
+	if s, ok := c.(Severitier); ok {
+		return s.Severity()
+	}
+
+	return 0
+}
+
+// codeCategory returns the category of c or 0 if it doesn't satisfy the
+// Categorizer interface or it doesn't have any.
+func codeCategory(c Code) CategoryKind {
+	if ct, ok := c.(Categorizer); ok {
+		return ct.Category()
+	}
+
+	return 0
+}
@@ -0,0 +1,171 @@
+package errors
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverityLevel(t *testing.T) {
+	var tcases = []struct {
+		sl       SeverityLevel
+		expStr   string
+		expLevel slog.Level
+	}{
+		{sl: SeverityDebug, expStr: "debug", expLevel: slog.LevelDebug},
+		{sl: SeverityInfo, expStr: "info", expLevel: slog.LevelInfo},
+		{sl: SeverityWarn, expStr: "warn", expLevel: slog.LevelWarn},
+		{sl: SeverityError, expStr: "error", expLevel: slog.LevelError},
+		{sl: SeverityFatal, expStr: "fatal", expLevel: slog.LevelError + 4},
+		{sl: SeverityLevel(0), expStr: "SeverityLevel(0)", expLevel: slog.LevelError},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.expStr, func(t *testing.T) {
+			assert.Equal(t, tc.expStr, tc.sl.String())
+			assert.Equal(t, tc.expLevel, tc.sl.Level())
+		})
+	}
+}
+
+func TestCategoryKind_String(t *testing.T) {
+	assert.Equal(t, "client", CategoryClient.String())
+	assert.Equal(t, "server", CategoryServer.String())
+	assert.Equal(t, "dependency", CategoryDependency.String())
+	assert.Equal(t, "security", CategorySecurity.String())
+	assert.Equal(t, "CategoryKind(9)", CategoryKind(9).String())
+}
+
+func TestSeverity(t *testing.T) {
+	var tcases = []struct {
+		desc     string
+		err      error
+		expSL    SeverityLevel
+		expFound bool
+	}{
+		{
+			desc:     "code with severity",
+			err:      New(severityTestCode(SeverityWarn)),
+			expSL:    SeverityWarn,
+			expFound: true,
+		},
+		{
+			desc:     "wrapped code with severity",
+			err:      fmt.Errorf("wrapped: %w", Wrap(New(severityTestCode(SeverityFatal)), testCode(true))),
+			expSL:    SeverityFatal,
+			expFound: true,
+		},
+		{
+			desc:     "outermost code with severity",
+			err:      Wrap(New(severityTestCode(SeverityFatal)), severityTestCode(SeverityInfo)),
+			expSL:    SeverityInfo,
+			expFound: true,
+		},
+		{
+			desc:     "outermost code with zero severity",
+			err:      Wrap(New(severityTestCode(SeverityWarn)), severityTestCode(0)),
+			expSL:    SeverityWarn,
+			expFound: true,
+		},
+		{
+			desc: "code with zero severity",
+			err:  New(severityTestCode(0)),
+		},
+		{
+			desc: "code without severity",
+			err:  New(testCode(true)),
+		},
+		{
+			desc: "not created by this package",
+			err:  fmt.Errorf("some error"),
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var sl, ok = Severity(tc.err)
+			assert.Equal(t, tc.expFound, ok)
+			assert.Equal(t, tc.expSL, sl)
+		})
+	}
+}
+
+func TestCategory(t *testing.T) {
+	var ck, ok = Category(fmt.Errorf("wrapped: %w", Wrap(New(severityTestCode(SeverityWarn)), testCode(true))))
+	assert.True(t, ok)
+	assert.Equal(t, CategoryDependency, ck)
+
+	ck, ok = Category(Wrap(New(severityTestCode(SeverityWarn)), undeclaredTestCode{}))
+	assert.True(t, ok)
+	assert.Equal(t, CategoryDependency, ck)
+
+	_, ok = Category(New(testCode(true)))
+	assert.False(t, ok)
+
+	_, ok = Category(New(undeclaredTestCode{}))
+	assert.False(t, ok)
+}
+
+func TestMoreSevere(t *testing.T) {
+	assert.True(t, MoreSevere(severityTestCode(SeverityError), severityTestCode(SeverityWarn)))
+	assert.False(t, MoreSevere(severityTestCode(SeverityWarn), severityTestCode(SeverityWarn)))
+	assert.True(t, MoreSevere(severityTestCode(SeverityDebug), testCode(true)))
+	assert.False(t, MoreSevere(testCode(true), severityTestCode(SeverityDebug)))
+	assert.False(t, MoreSevere(undeclaredTestCode{}, testCode(true)))
+	assert.True(t, MoreSevere(severityTestCode(SeverityDebug), undeclaredTestCode{}))
+
+	var c, ok = GetCodeFunc(Join(
+		New(testCode(true)),
+		New(severityTestCode(SeverityWarn)),
+		New(severityTestCode(SeverityFatal)),
+		New(severityTestCode(SeverityInfo)),
+	), MoreSevere)
+	assert.True(t, ok)
+	assert.Equal(t, severityTestCode(SeverityFatal), c)
+}
+
+// severityTestCode is a silly example of a Code implementation with the only
+// purpose of testing the severity and category of the Codes; its value is its
+// severity level and its category is always CategoryDependency.
+type severityTestCode SeverityLevel
+
+func (severityTestCode) String() string {
+	return "SeverityTestCode"
+}
+
+func (severityTestCode) Message() string {
+	return "a test error with severity has happened"
+}
+
+func (c severityTestCode) Severity() SeverityLevel {
+	return SeverityLevel(c)
+}
+
+func (severityTestCode) Category() CategoryKind {
+	return CategoryDependency
+}
+
+// undeclaredTestCode is a silly example of a Code implementation with the only
+// purpose of testing the Codes which satisfy the Severitier and Categorizer
+// interfaces without declaring any severity level nor category.
+type undeclaredTestCode struct{}
+
+func (undeclaredTestCode) String() string {
+	return "UndeclaredTestCode"
+}
+
+func (undeclaredTestCode) Message() string {
+	return "a test error without severity has happened"
+}
+
+func (undeclaredTestCode) Severity() SeverityLevel {
+	return 0
+}
+
+func (undeclaredTestCode) Category() CategoryKind {
+	return 0
+}
@@ -0,0 +1,175 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// LogValue satisfies the slog.LogValuer interface.
+// It returns a group with the attributes "code", "message", "id" and
+// "metadata", which is a group with an attribute for each metadata, whose
+// value is RedactedValue for the secret ones.
+// The wrapped error and the call stack are only added by the handlers returned
+// by NewSlogHandler when they are configured for it.
+func (err derror) LogValue() slog.Value {
+	return slog.GroupValue(err.logAttrs()...)
+}
+
+func (err derror) logAttrs() []slog.Attr {
+	var attrs = []slog.Attr{
+		slog.String("code", err.c.String()),
+		slog.String("message", Message(err)),
+		slog.String("id", err.id.String()),
+	}
+
+	if len(err.mds) > 0 {
+		var mattrs = make([]slog.Attr, len(err.mds))
+		for i, md := range err.mds {
+			mattrs[i] = slog.Any(md.K, md.value())
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "metadata", Value: slog.GroupValue(mattrs...)})
+	}
+
+	return attrs
+}
+
+// SlogHandlerOptions are the options of the handlers returned by
+// NewSlogHandler.
+type SlogHandlerOptions struct {
+	// WrappedError adds the attribute "wrapped" with the wrapped error. When
+	// the wrapped error is created by one of the constructors of this package,
+	// it's a group with the same attributes than the error, otherwise it's a
+	// group with the attribute "message", containing the error message, and
+	// the attribute "wrapped" if it wraps another error.
+	WrappedError bool
+	// CallStack adds the attribute "call_stack" with a list of the call stack
+	// frames, each of them formatted as "function file:line".
+	CallStack bool
+}
+
+// slogHandler is the slog.Handler returned by NewSlogHandler.
+type slogHandler struct {
+	h    slog.Handler
+	opts SlogHandlerOptions
+}
+
+// NewSlogHandler returns a slog.Handler which passes the records to h after
+// expanding the attributes whose values are errors created by one of the
+// constructors of this package, included the ones which are in their chain, in
+// the same way than their LogValue method does, plus the wrapped error and the
+// call stack according to opts, which can be nil.
+// When the error isn't the one which is in the chain, the attribute "error",
+// with the error message, is also added.
+func NewSlogHandler(h slog.Handler, opts *SlogHandlerOptions) slog.Handler {
+	var sh = &slogHandler{h: h}
+	if opts != nil {
+		sh.opts = *opts
+	}
+
+	return sh
+}
+
+// Enabled satisfies the slog.Handler interface.
+func (sh *slogHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return sh.h.Enabled(ctx, l)
+}
+
+// Handle satisfies the slog.Handler interface.
+func (sh *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var nr = slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(sh.expand(a))
+		return true
+	})
+
+	return sh.h.Handle(ctx, nr)
+}
+
+// WithAttrs satisfies the slog.Handler interface.
+func (sh *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var eattrs = make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		eattrs[i] = sh.expand(a)
+	}
+
+	return &slogHandler{h: sh.h.WithAttrs(eattrs), opts: sh.opts}
+}
+
+// WithGroup satisfies the slog.Handler interface.
+func (sh *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{h: sh.h.WithGroup(name), opts: sh.opts}
+}
+
+// expand returns a with its value expanded when it's an error which has an
+// error created by one of the constructors of this package in its chain.
+// Groups are expanded recursively.
+func (sh *slogHandler) expand(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		var (
+			gattrs = a.Value.Group()
+			eattrs = make([]slog.Attr, len(gattrs))
+		)
+
+		for i, ga := range gattrs {
+			eattrs[i] = sh.expand(ga)
+		}
+
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(eattrs...)}
+	case slog.KindAny, slog.KindLogValuer:
+		var err, ok = a.Value.Any().(error)
+		if !ok {
+			return a
+		}
+
+		var derr, found = first(err)
+		if !found {
+			return a
+		}
+
+		var attrs = sh.attrs(derr)
+		if _, ok := err.(derror); !ok {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	default:
+		return a
+	}
+}
+
+// attrs returns the attributes of derr according to the handler options.
+func (sh *slogHandler) attrs(derr derror) []slog.Attr {
+	var attrs = derr.logAttrs()
+
+	if sh.opts.WrappedError && derr.werr != nil {
+		attrs = append(attrs, slog.Attr{Key: "wrapped", Value: sh.wrappedValue(derr.werr)})
+	}
+
+	if sh.opts.CallStack && len(derr.cs) > 0 {
+		var fss []string
+		for _, f := range derr.cs.frames() {
+			fss = append(fss, fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line))
+		}
+
+		attrs = append(attrs, slog.Any("call_stack", fss))
+	}
+
+	return attrs
+}
+
+// wrappedValue returns the value of the "wrapped" attribute for err.
+func (sh *slogHandler) wrappedValue(err error) slog.Value {
+	if derr, ok := err.(derror); ok {
+		return slog.GroupValue(sh.attrs(derr)...)
+	}
+
+	var attrs = []slog.Attr{slog.String("message", err.Error())}
+	if uerr, ok := err.(interface{ Unwrap() error }); ok && uerr.Unwrap() != nil {
+		attrs = append(attrs, slog.Attr{Key: "wrapped", Value: sh.wrappedValue(uerr.Unwrap())})
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerror_LogValue(t *testing.T) {
+	var (
+		err = Wrap(errors.New("ext error"), testCode(true),
+			MD{K: "var1", V: "a string"}, MD{K: "var2", V: 10}, SecretMD("var3", "secret"),
+		)
+		derr   = err.(derror)
+		buf    bytes.Buffer
+		logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	)
+
+	logger.Error("some message", "error", err)
+
+	var rec map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	assert.Equal(t, map[string]interface{}{
+		"code":    "TestCode",
+		"message": "an test code error has happened",
+		"id":      derr.id.String(),
+		"metadata": map[string]interface{}{
+			"var1": "a string",
+			"var2": float64(10),
+			"var3": "[REDACTED]",
+		},
+	}, rec["error"])
+}
+
+func TestNewSlogHandler(t *testing.T) {
+	var (
+		inner = New(differentTestCode(true), MD{K: "n", V: 1})
+		err   = Wrap(fmt.Errorf("some context: %w", inner), testCode(true))
+	)
+
+	var logRecord = func(t *testing.T, opts *SlogHandlerOptions, args ...interface{}) map[string]interface{} {
+		var (
+			buf    bytes.Buffer
+			logger = slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil), opts))
+		)
+
+		logger.Error("some message", args...)
+
+		var rec map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+		return rec
+	}
+
+	t.Run("without options", func(t *testing.T) {
+		var rec = logRecord(t, nil, "error", err)
+		assert.Equal(t, map[string]interface{}{
+			"code":    "TestCode",
+			"message": "an test code error has happened",
+			"id":      err.(derror).id.String(),
+		}, rec["error"])
+	})
+
+	t.Run("with wrapped error", func(t *testing.T) {
+		var rec = logRecord(t, &SlogHandlerOptions{WrappedError: true}, "error", err)
+		assert.Equal(t, map[string]interface{}{
+			"code":    "TestCode",
+			"message": "an test code error has happened",
+			"id":      err.(derror).id.String(),
+			"wrapped": map[string]interface{}{
+				"message": errors.Unwrap(err).Error(),
+				"wrapped": map[string]interface{}{
+					"code":     "DifferentError",
+					"message":  "This is a different error",
+					"id":       inner.(derror).id.String(),
+					"metadata": map[string]interface{}{"n": float64(1)},
+				},
+			},
+		}, rec["error"])
+	})
+
+	t.Run("with call stack", func(t *testing.T) {
+		var rec = logRecord(t, &SlogHandlerOptions{CallStack: true}, "error", err)
+
+		var cs = rec["error"].(map[string]interface{})["call_stack"].([]interface{})
+		require.NotEmpty(t, cs)
+		assert.True(t, strings.HasPrefix(cs[0].(string), "go.fraixed.es/errors.TestNewSlogHandler"))
+		assert.Contains(t, cs[0], "slog_test.go:")
+	})
+
+	t.Run("error in the chain", func(t *testing.T) {
+		var (
+			werr = fmt.Errorf("some context: %w", inner)
+			rec  = logRecord(t, nil, "error", werr)
+		)
+
+		assert.Equal(t, map[string]interface{}{
+			"code":     "DifferentError",
+			"message":  "This is a different error",
+			"id":       inner.(derror).id.String(),
+			"metadata": map[string]interface{}{"n": float64(1)},
+			"error":    werr.Error(),
+		}, rec["error"])
+	})
+
+	t.Run("error in a group and in the logger attributes", func(t *testing.T) {
+		var (
+			buf    bytes.Buffer
+			logger = slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil), &SlogHandlerOptions{CallStack: true}))
+		)
+
+		logger.With("cause", inner).WithGroup("g").Error("some message", slog.Group("sub", "error", err))
+
+		var rec map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+
+		assert.Contains(t, rec["cause"], "call_stack")
+		var gerr = rec["g"].(map[string]interface{})["sub"].(map[string]interface{})["error"]
+		assert.Contains(t, gerr, "call_stack")
+	})
+
+	t.Run("other errors and values", func(t *testing.T) {
+		var rec = logRecord(t, nil, "error", errors.New("some error"), "n", 10)
+		assert.Equal(t, "some error", rec["error"])
+		assert.Equal(t, float64(10), rec["n"])
+	})
+
+	t.Run("disabled level", func(t *testing.T) {
+		var h = NewSlogHandler(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}), nil)
+		assert.False(t, h.Enabled(context.Background(), slog.LevelInfo))
+	})
+}
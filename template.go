@@ -0,0 +1,236 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tmplPart is a part of a parsed message template; it's a literal text or a
+// placeholder.
+type tmplPart struct {
+	text  string
+	isKey bool
+}
+
+// parseTemplate parses the message template msg.
+// It returns an error with the ErrCodeMalformedTemplate Code if msg has a
+// placeholder which isn't closed, an empty placeholder, or an unescaped closing
+// brace.
+func parseTemplate(msg string) ([]tmplPart, error) {
+	var (
+		parts []tmplPart
+		lit   strings.Builder
+	)
+
+	for i := 0; i < len(msg); i++ {
+		switch msg[i] {
+		case '{':
+			if i+1 < len(msg) && msg[i+1] == '{' {
+				lit.WriteByte('{')
+				i++
+				continue
+			}
+
+			var end = strings.IndexAny(msg[i+1:], "{}")
+			if end <= 0 || msg[i+1+end] != '}' {
+				return nil, New(ErrCodeMalformedTemplate,
+					MD{K: "template", V: msg}, MD{K: "position", V: i},
+				)
+			}
+
+			if lit.Len() > 0 {
+				parts = append(parts, tmplPart{text: lit.String()})
+				lit.Reset()
+			}
+
+			parts = append(parts, tmplPart{text: msg[i+1 : i+1+end], isKey: true})
+			i += end + 1
+		case '}':
+			if i+1 < len(msg) && msg[i+1] == '}' {
+				lit.WriteByte('}')
+				i++
+				continue
+			}
+
+			return nil, New(ErrCodeMalformedTemplate,
+				MD{K: "template", V: msg}, MD{K: "position", V: i},
+			)
+		default:
+			lit.WriteByte(msg[i])
+		}
+	}
+
+	if lit.Len() > 0 {
+		parts = append(parts, tmplPart{text: lit.String()})
+	}
+
+	return parts, nil
+}
+
+// MessageTemplater is the interface that the Codes satisfy for opting in to
+// message templates. MessageTemplate returns a template which can refer to the
+// metadata of the errors through placeholders, which are metadata keys
+// enclosed by braces, and whose literal braces are escaped by doubling them.
+//
+// The messages of the Codes which don't satisfy it are never considered
+// templates, so they can have braces.
+type MessageTemplater interface {
+	MessageTemplate() string
+}
+
+// TemplateOf returns the message template of c, which is the one returned by
+// its MessageTemplate method when it satisfies the MessageTemplater interface,
+// otherwise its message with the braces escaped.
+func TemplateOf(c Code) string {
+	if mt, ok := c.(MessageTemplater); ok {
+		return mt.MessageTemplate()
+	}
+
+	return escapeBraces.Replace(c.Message())
+}
+
+// escapeBraces escapes the braces of a text for using it as a message
+// template.
+var escapeBraces = strings.NewReplacer("{", "{{", "}", "}}")
+
+// ValidateMessage returns an error with the ErrCodeMalformedTemplate Code if
+// the message template msg is malformed, otherwise nil.
+//
+// The Registry validates the message templates of the Codes which satisfy the
+// MessageTemplater interface when they are registered.
+func ValidateMessage(msg string) error {
+	var _, err = parseTemplate(msg)
+	return err
+}
+
+// ExpandMessage returns the message template msg with its placeholders
+// replaced by the values of the metadata of mds with the same key; when
+// several have the same key, the first one is used. The placeholders of the
+// secret metadata are replaced by RedactedValue.
+//
+// When mds doesn't have the key of some placeholders, they are replaced by
+// "%!{key}(MISSING)" and an error with the ErrCodeMissingTemplateKey Code, with
+// the missing keys as metadata, is returned.
+//
+// When msg is malformed, msg is returned as it is with the error returned by
+// ValidateMessage.
+func ExpandMessage(msg string, mds ...MD) (string, error) {
+	var parts, err = parseTemplate(msg)
+	if err != nil {
+		return msg, err
+	}
+
+	var (
+		sb      strings.Builder
+		missing []string
+	)
+	for _, p := range parts {
+		if !p.isKey {
+			sb.WriteString(p.text)
+			continue
+		}
+
+		var md, ok = lookupMD(mds, p.text)
+		if !ok {
+			missing = append(missing, p.text)
+			_, _ = fmt.Fprintf(&sb, "%%!{%s}(MISSING)", p.text)
+			continue
+		}
+
+		_, _ = fmt.Fprint(&sb, md.value())
+	}
+
+	if len(missing) > 0 {
+		return sb.String(), New(ErrCodeMissingTemplateKey, MD{K: "keys", V: missing})
+	}
+
+	return sb.String(), nil
+}
+
+// Message returns the message of the Code of err (see GetCode); when the Code
+// satisfies the MessageTemplater interface, it's its message template with the
+// placeholders replaced by the metadata of err (see AllMD) as ExpandMessage
+// does, reporting the missing keys in the returned message.
+//
+// It returns the message of err (i.e. err.Error()) if err doesn't have any
+// Code and an empty string if err is nil.
+//
+// The returned message may have internal metadata, hence it isn't meant to be
+// exposed to the users; see PublicMessage.
+func Message(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var derr, ok = first(err)
+	if !ok {
+		return err.Error()
+	}
+
+	var mt, isTmpl = derr.c.(MessageTemplater)
+	if !isTmpl {
+		return derr.c.Message()
+	}
+
+	var msg, _ = ExpandMessage(mt.MessageTemplate(), AllMD(err)...)
+	return msg
+}
+
+// PublicMessage is like Message but it only fills the placeholders with the
+// public metadata of err (see SensitivityPublic), so the returned message can
+// be exposed to the users. When the message template cannot be filled, because
+// some keys aren't in the public metadata or it's malformed, it returns the
+// message of the Code.
+//
+// It returns an empty string if err doesn't have any Code, because its message
+// may have internal information, or if it's nil.
+func PublicMessage(err error) string {
+	var derr, ok = first(err)
+	if !ok {
+		return ""
+	}
+
+	return PublicCodeMessage(derr.c, AllMD(err)...)
+}
+
+// PublicCodeMessage returns the message template of c (see TemplateOf) filled
+// with the public metadata of mds; when it cannot be filled, because some keys
+// aren't in the public metadata or it's malformed, it returns the message of
+// c.
+func PublicCodeMessage(c Code, mds ...MD) string {
+	var mt, ok = c.(MessageTemplater)
+	if !ok {
+		return c.Message()
+	}
+
+	var msg, err = ExpandMessage(mt.MessageTemplate(), PublicMDs(mds)...)
+	if err != nil {
+		return c.Message()
+	}
+
+	return msg
+}
+
+// PublicMDs returns the metadata of mds which are public (see
+// SensitivityPublic) keeping their order.
+func PublicMDs(mds []MD) []MD {
+	var pmds []MD
+	for _, md := range mds {
+		if md.S == SensitivityPublic {
+			pmds = append(pmds, md)
+		}
+	}
+
+	return pmds
+}
+
+// lookupMD returns the first metadata of mds whose key is k.
+func lookupMD(mds []MD, k string) (MD, bool) {
+	for _, md := range mds {
+		if md.K == k {
+			return md, true
+		}
+	}
+
+	return MD{}, false
+}
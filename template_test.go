@@ -0,0 +1,281 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMessage(t *testing.T) {
+	var tcases = []struct {
+		desc   string
+		msg    string
+		expErr bool
+	}{
+		{desc: "without placeholders", msg: "an error has happened"},
+		{desc: "with placeholders", msg: "user {user_id} not found in {db}"},
+		{desc: "with escaped braces", msg: "invalid JSON {{}}, user {user_id}"},
+		{desc: "not closed placeholder", msg: "user {user_id not found", expErr: true},
+		{desc: "nested placeholder", msg: "user {user_{id}} not found", expErr: true},
+		{desc: "empty placeholder", msg: "user {} not found", expErr: true},
+		{desc: "unescaped closing brace", msg: "user } not found", expErr: true},
+		{desc: "opening brace at the end", msg: "user {", expErr: true},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var err = ValidateMessage(tc.msg)
+			if tc.expErr {
+				assert.True(t, Is(err, ErrCodeMalformedTemplate))
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestExpandMessage(t *testing.T) {
+	var tcases = []struct {
+		desc       string
+		msg        string
+		mds        []MD
+		expMsg     string
+		expErrCode Code
+		expMissing []string
+	}{
+		{
+			desc:   "without placeholders",
+			msg:    "an error has happened",
+			mds:    []MD{{K: "user_id", V: 10}},
+			expMsg: "an error has happened",
+		},
+		{
+			desc:   "with placeholders",
+			msg:    "user {user_id} not found in {db}",
+			mds:    []MD{{K: "db", V: "users"}, {K: "user_id", V: 10}, {K: "user_id", V: 11}},
+			expMsg: "user 10 not found in users",
+		},
+		{
+			desc:   "with escaped braces",
+			msg:    "invalid JSON {{{doc}}}",
+			mds:    []MD{{K: "doc", V: "a"}},
+			expMsg: "invalid JSON {a}",
+		},
+		{
+			desc:   "secret metadata",
+			msg:    "user {email} not found",
+			mds:    []MD{SecretMD("email", "ivan@example.com")},
+			expMsg: "user [REDACTED] not found",
+		},
+		{
+			desc:       "missing keys",
+			msg:        "user {user_id} not found in {db}",
+			expMsg:     "user %!{user_id}(MISSING) not found in %!{db}(MISSING)",
+			expErrCode: ErrCodeMissingTemplateKey,
+			expMissing: []string{"user_id", "db"},
+		},
+		{
+			desc:       "malformed",
+			msg:        "user {user_id not found",
+			mds:        []MD{{K: "user_id", V: 10}},
+			expMsg:     "user {user_id not found",
+			expErrCode: ErrCodeMalformedTemplate,
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var msg, err = ExpandMessage(tc.msg, tc.mds...)
+			assert.Equal(t, tc.expMsg, msg)
+			if tc.expErrCode == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, Is(err, tc.expErrCode))
+			if tc.expMissing != nil {
+				var v, _ = LookupMD(err, "keys")
+				assert.Equal(t, tc.expMissing, v)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	var tcases = []struct {
+		desc   string
+		err    error
+		expMsg string
+	}{
+		{
+			desc:   "code without placeholders",
+			err:    New(testCode(true), MD{K: "user_id", V: 10}),
+			expMsg: testCode(true).Message(),
+		},
+		{
+			desc: "wrapped metadata",
+			err: fmt.Errorf("wrapped: %w", Wrap(
+				New(testCode(true), MD{K: "user_id", V: 10}, MD{K: "db", V: "users"}),
+				tmplTestCode("user {user_id} not found in {db}"),
+				MD{K: "user_id", V: 11},
+			)),
+			expMsg: "user 11 not found in users",
+		},
+		{
+			desc:   "missing keys",
+			err:    New(tmplTestCode("user {user_id} not found")),
+			expMsg: "user %!{user_id}(MISSING) not found",
+		},
+		{
+			desc:   "code which doesn't satisfy MessageTemplater",
+			err:    New(literalTestCode("invalid JSON {user_id}"), MD{K: "user_id", V: 10}),
+			expMsg: "invalid JSON {user_id}",
+		},
+		{
+			desc:   "error without code",
+			err:    fmt.Errorf("some error"),
+			expMsg: "some error",
+		},
+		{
+			desc:   "nil error",
+			expMsg: "",
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expMsg, Message(tc.err))
+		})
+	}
+}
+
+func TestPublicMessage(t *testing.T) {
+	var tcases = []struct {
+		desc   string
+		err    error
+		expMsg string
+	}{
+		{
+			desc:   "public metadata",
+			err:    New(tmplTestCode("user {user_id} not found"), PublicMD("user_id", 10)),
+			expMsg: "user 10 not found",
+		},
+		{
+			desc: "internal metadata",
+			err: New(tmplTestCode("user {user_id} not found"),
+				MD{K: "user_id", V: 10},
+			),
+			expMsg: "user {user_id} not found",
+		},
+		{
+			desc: "wrapped public metadata",
+			err: Wrap(
+				New(testCode(true), PublicMD("db", "users")),
+				tmplTestCode("user {user_id} not found in {db}"),
+				PublicMD("user_id", 11),
+			),
+			expMsg: "user 11 not found in users",
+		},
+		{
+			desc:   "code which doesn't satisfy MessageTemplater",
+			err:    New(literalTestCode("invalid JSON {user_id}"), PublicMD("user_id", 10)),
+			expMsg: "invalid JSON {user_id}",
+		},
+		{
+			desc:   "error without code",
+			err:    fmt.Errorf("some error"),
+			expMsg: "",
+		},
+		{
+			desc:   "nil error",
+			expMsg: "",
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expMsg, PublicMessage(tc.err))
+		})
+	}
+}
+
+func TestTemplateOf(t *testing.T) {
+	assert.Equal(t, "user {user_id} not found", TemplateOf(tmplTestCode("user {user_id} not found")))
+	assert.Equal(t, "invalid JSON {{}}", TemplateOf(literalTestCode("invalid JSON {}")))
+
+	var msg, err = ExpandMessage(TemplateOf(literalTestCode("invalid JSON {}")))
+	require.NoError(t, err)
+	assert.Equal(t, "invalid JSON {}", msg)
+}
+
+func TestDerror_Format_template(t *testing.T) {
+	var (
+		c   = tmplTestCode("user {user_id} not found")
+		err = New(c, MD{K: "user_id", V: 10})
+	)
+
+	assert.Equal(t, "TmplTestCode: user 10 not found", fmt.Sprintf("%s", err))
+	assert.Equal(t, "TmplTestCode: user 10 not found", err.Error())
+
+	var id, _ = GetID(err)
+	assert.Equal(t,
+		fmt.Sprintf("TmplTestCode: user 10 not found\n\tid: %s\n\tmetadata: [{\"user_id\": 10}]", id),
+		fmt.Sprintf("%v", err),
+	)
+
+	// Errors with the same Code are the same independently of their metadata.
+	assert.True(t, Is(err, c))
+	assert.True(t, Is(New(c, MD{K: "user_id", V: 11}), c))
+}
+
+func TestRegistry_Register_template(t *testing.T) {
+	var r = NewRegistry()
+	require.NoError(t, r.Register(tmplTestCode("user {user_id} not found")))
+
+	var err = NewRegistry().Register(testCode(true), tmplTestCode("user {user_id not found"))
+	assert.True(t, Is(err, ErrCodeMalformedTemplate))
+
+	var v, _ = LookupMD(err, "code")
+	assert.Equal(t, "TmplTestCode", v)
+
+	// The messages of the Codes which don't satisfy MessageTemplater aren't
+	// templates.
+	assert.NoError(t, NewRegistry().Register(literalTestCode("user {user_id not found")))
+}
+
+// tmplTestCode is a silly example of a Code implementation with the only
+// purpose of testing the message templates; its message and its message
+// template are its value.
+type tmplTestCode string
+
+func (tmplTestCode) String() string {
+	return "TmplTestCode"
+}
+
+func (c tmplTestCode) Message() string {
+	return string(c)
+}
+
+func (c tmplTestCode) MessageTemplate() string {
+	return string(c)
+}
+
+// literalTestCode is a silly example of a Code implementation with the only
+// purpose of testing the Codes whose messages aren't templates; its message is
+// its value.
+type literalTestCode string
+
+func (literalTestCode) String() string {
+	return "LiteralTestCode"
+}
+
+func (c literalTestCode) Message() string {
+	return string(c)
+}
@@ -0,0 +1,36 @@
+package validation
+
+import "go.fraixed.es/errors"
+
+// ErrCode is the type of the Codes of the errors returned by this package.
+type ErrCode uint8
+
+const (
+	// ErrCodeInvalid identifies the errors which aggregate the violations of
+	// an input.
+	ErrCodeInvalid ErrCode = iota + 1
+)
+
+func init() {
+	errors.MustRegister(ErrCodeInvalid)
+}
+
+// String satisfies the errors.Code interface.
+func (c ErrCode) String() string {
+	switch c {
+	case ErrCodeInvalid:
+		return "validation.Invalid"
+	default:
+		return "validation.UnknownCode"
+	}
+}
+
+// Message satisfies the errors.Code interface.
+func (c ErrCode) Message() string {
+	switch c {
+	case ErrCodeInvalid:
+		return "the input is invalid"
+	default:
+		return "unknown error code of the validation package"
+	}
+}
@@ -0,0 +1,221 @@
+package validation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+
+	"go.fraixed.es/errors"
+)
+
+// FieldMDKey is the metadata key of the violations which holds the field path.
+const FieldMDKey = "field"
+
+// Collector collects violations. The zero value is ready to use.
+// A Collector isn't safe for concurrent use.
+type Collector struct {
+	errs []error
+}
+
+// Add adds a violation of the field p identified by c with params.
+// Only the public params (see errors.PublicMD) are shown to the API clients,
+// the rest keep their sensitivity.
+func (c *Collector) Add(p Path, code errors.Code, params ...errors.MD) {
+	var mds = make([]errors.MD, 0, len(params)+1)
+	mds = append(mds, errors.PublicMD(FieldMDKey, p.String()))
+	mds = append(mds, params...)
+
+	c.errs = append(c.errs, errors.NewSkip(1, code, mds...))
+}
+
+// Len returns the number of collected violations.
+func (c *Collector) Len() int {
+	return len(c.errs)
+}
+
+// Err returns an error with the ErrCodeInvalid Code and mds which aggregates
+// all the collected violations; it returns nil if there isn't any.
+// errors.Is, errors.HasCode, etc. report the Codes of the violations as well
+// as ErrCodeInvalid, which is the Code returned by errors.GetCode.
+func (c *Collector) Err(mds ...errors.MD) error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	var errs = make([]error, len(c.errs))
+	copy(errs, c.errs)
+
+	return invalidError{err: errors.NewSkip(1, ErrCodeInvalid, mds...), errs: errs}
+}
+
+// invalidError is the error returned by Collector.Err. It's printed as the
+// error with the ErrCodeInvalid Code, and it's unwrapped as such error followed
+// by the violations, so errors.Is considers all of them.
+type invalidError struct {
+	err  error
+	errs []error
+}
+
+// Error satisfies the error interface.
+func (e invalidError) Error() string {
+	return e.err.Error()
+}
+
+// Format satisfies the fmt.Formatter interface printing the error with the
+// ErrCodeInvalid Code.
+func (e invalidError) Format(state fmt.State, verb rune) {
+	e.err.(fmt.Formatter).Format(state, verb)
+}
+
+// Unwrap returns the error with the ErrCodeInvalid Code followed by the
+// violations.
+func (e invalidError) Unwrap() []error {
+	return append([]error{e.err}, e.errs...)
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// It's the same output than errors.ToJSON(e, false), so it has the internal
+// metadata; see ToJSON for the JSON representation for the API clients.
+func (e invalidError) MarshalJSON() ([]byte, error) {
+	return errors.ToJSON(e, false)
+}
+
+// Violation is a violation of a field.
+type Violation struct {
+	// Field is the path of the field.
+	Field Path
+	// Code identifies the violation.
+	Code errors.Code
+	// ID is the ID of the violation error.
+	ID errors.ID
+	// Params are the parameters of the violation, excluding the field path.
+	Params []errors.MD
+}
+
+// Violations returns the violations aggregated by err, which must be an error
+// returned by Collector.Err or that wraps it; if there isn't any, it returns
+// nil.
+func Violations(err error) []Violation {
+	var _, verrs = aggregate(err)
+	if verrs == nil {
+		return nil
+	}
+
+	var vs = make([]Violation, 0, len(verrs))
+	for _, verr := range verrs {
+		var (
+			code, _ = errors.GetCode(verr)
+			id, _   = errors.GetID(verr)
+			v       = Violation{Code: code, ID: id}
+		)
+
+		for _, md := range errors.GetMD(verr) {
+			if md.K == FieldMDKey {
+				if s, ok := md.V.(string); ok {
+					v.Field = Path(s)
+					continue
+				}
+			}
+
+			v.Params = append(v.Params, md)
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs
+}
+
+// aggregate returns the error with the ErrCodeInvalid Code of the first
+// aggregate of violations, as the ones returned by Collector.Err, of the chain
+// of err and the violations. It returns nil, nil if there isn't any.
+func aggregate(err error) (error, []error) {
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		var merr, ok = err.(interface{ Unwrap() []error })
+		if !ok {
+			continue
+		}
+
+		// The aggregates are unwrapped as an error with the ErrCodeInvalid
+		// Code followed by the violations.
+		if errs := merr.Unwrap(); len(errs) > 0 && isInvalid(errs[0]) {
+			return errs[0], errs[1:]
+		}
+	}
+
+	return nil, nil
+}
+
+// isInvalid returns true if the outermost Code of err is ErrCodeInvalid and it
+// isn't an aggregate of errors, otherwise false.
+func isInvalid(err error) bool {
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		return false
+	}
+
+	return errors.Is(err, ErrCodeInvalid)
+}
+
+// jsonViolation is the JSON representation of a Violation.
+type jsonViolation struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	ID      string                 `json:"id"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// It returns a JSON object with the fields "field", "code", "message", which is
+// the message of the Code filled with the public parameters (see
+// errors.PublicCodeMessage), "id" and "params", which is an object with the
+// public parameters.
+func (v Violation) MarshalJSON() ([]byte, error) {
+	var jv = jsonViolation{
+		Field: v.Field.String(),
+		ID:    v.ID.String(),
+	}
+
+	for _, md := range errors.PublicMDs(v.Params) {
+		if jv.Params == nil {
+			jv.Params = map[string]interface{}{}
+		}
+
+		jv.Params[md.K] = md.V
+	}
+
+	if v.Code != nil {
+		jv.Code = v.Code.String()
+		jv.Message = errors.PublicCodeMessage(v.Code, v.Params...)
+	}
+
+	return json.Marshal(jv)
+}
+
+// ToJSON returns the JSON representation of err, which must be an error
+// returned by Collector.Err or that wraps it, for the API clients. It's a JSON
+// object with the fields "code", "message", "id" and "violations", which is an
+// array with the violations (see Violation.MarshalJSON).
+func ToJSON(err error) ([]byte, error) {
+	var doc = struct {
+		Code       string      `json:"code"`
+		Message    string      `json:"message"`
+		ID         string      `json:"id"`
+		Violations []Violation `json:"violations"`
+	}{
+		Code:       ErrCodeInvalid.String(),
+		Message:    ErrCodeInvalid.Message(),
+		Violations: Violations(err),
+	}
+
+	if aerr, _ := aggregate(err); aerr != nil {
+		var id, _ = errors.GetID(aerr)
+		doc.ID = id.String()
+	}
+
+	if doc.Violations == nil {
+		doc.Violations = []Violation{}
+	}
+
+	return json.Marshal(doc)
+}
@@ -0,0 +1,211 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fraixed.es/errors"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("no violations", func(t *testing.T) {
+		var c Collector
+		assert.Equal(t, 0, c.Len())
+		assert.NoError(t, c.Err())
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		var c Collector
+		c.Add(Field("name"), requiredTestCode(true))
+		c.Add(
+			Field("items").Index(3).Field("price"), minTestCode(true),
+			errors.PublicMD("min", 0), errors.MD{K: "value", V: -1},
+		)
+		assert.Equal(t, 2, c.Len())
+
+		var err = c.Err(errors.MD{K: "request", V: "r1"})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, ErrCodeInvalid))
+		assert.True(t, errors.Is(err, requiredTestCode(true)))
+		assert.True(t, errors.Is(err, minTestCode(true)))
+		assert.False(t, errors.Is(err, lengthTestCode(true)))
+		assert.True(t, errors.HasCode(err, requiredTestCode(true)))
+		assert.True(t, errors.HasCode(err, minTestCode(true)))
+		assert.Equal(t, "validation.Invalid: the input is invalid", err.Error())
+
+		var code, _ = errors.GetCode(err)
+		assert.Equal(t, ErrCodeInvalid.String(), code.String())
+
+		var (
+			id, _ = errors.GetID(err)
+			vs    = Violations(err)
+		)
+		require.Len(t, vs, 2)
+		for _, v := range vs {
+			assert.NotEqual(t, id.String(), v.ID.String())
+		}
+
+		assert.Equal(t, "name", vs[0].Field.String())
+		assert.Equal(t, requiredTestCode(true).String(), vs[0].Code.String())
+		assert.Empty(t, vs[0].Params)
+
+		assert.Equal(t, "items[3].price", vs[1].Field.String())
+		assert.Equal(t, minTestCode(true).String(), vs[1].Code.String())
+		assert.Equal(t, []errors.MD{
+			{K: "min", V: 0, S: errors.SensitivityPublic},
+			{K: "value", V: -1},
+		}, vs[1].Params)
+
+		v, ok := errors.LookupMD(err, "request")
+		assert.True(t, ok)
+		assert.Equal(t, "r1", v)
+	})
+
+	t.Run("wrapped aggregate", func(t *testing.T) {
+		var c Collector
+		c.Add(Field("name"), requiredTestCode(true))
+
+		var err = fmt.Errorf("creating user: %w", c.Err())
+		assert.True(t, errors.Is(err, requiredTestCode(true)))
+
+		var vs = Violations(err)
+		require.Len(t, vs, 1)
+		assert.Equal(t, "name", vs[0].Field.String())
+	})
+
+	t.Run("marshal JSON", func(t *testing.T) {
+		var c Collector
+		c.Add(Field("name"), requiredTestCode(true))
+
+		var b, jerr = json.Marshal(c.Err())
+		require.NoError(t, jerr)
+
+		var doc struct {
+			Errors []struct {
+				Code string `json:"code"`
+			} `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(b, &doc))
+		require.Len(t, doc.Errors, 2)
+		assert.Equal(t, ErrCodeInvalid.String(), doc.Errors[0].Code)
+		assert.Equal(t, requiredTestCode(true).String(), doc.Errors[1].Code)
+	})
+}
+
+func TestViolations(t *testing.T) {
+	t.Run("JSON round trip", func(t *testing.T) {
+		var c Collector
+		c.Add(Field("name"), requiredTestCode(true))
+
+		var data, err = errors.ToJSON(c.Err(), false)
+		require.NoError(t, err)
+
+		jerr, err := errors.FromJSON(data, nil)
+		require.NoError(t, err)
+		assert.True(t, errors.Is(jerr, ErrCodeInvalid))
+
+		var vs = Violations(jerr)
+		require.Len(t, vs, 1)
+		assert.Equal(t, "name", vs[0].Field.String())
+	})
+
+	assert.Nil(t, Violations(nil))
+	assert.Nil(t, Violations(fmt.Errorf("other")))
+	assert.Nil(t, Violations(errors.New(requiredTestCode(true))))
+}
+
+func TestToJSON(t *testing.T) {
+	var c Collector
+	c.Add(Field("name"), requiredTestCode(true))
+	c.Add(Field("age"), minTestCode(true), errors.PublicMD("min", 18), errors.MD{K: "value", V: 16})
+
+	var err = c.Err()
+	var (
+		id, _ = errors.GetID(err)
+		vs    = Violations(err)
+	)
+	require.Len(t, vs, 2)
+
+	data, jerr := ToJSON(err)
+	require.NoError(t, jerr)
+
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"code": "validation.Invalid",
+		"message": "the input is invalid",
+		"id": %q,
+		"violations": [
+			{"field": "name", "code": "RequiredTestCode", "message": "the field is required", "id": %q},
+			{"field": "age", "code": "MinTestCode", "message": "the field is lower than the minimum", "id": %q, "params": {"min": 18}}
+		]
+	}`, id, vs[0].ID, vs[1].ID), string(data))
+}
+
+func TestViolation_MarshalJSON_template(t *testing.T) {
+	var tcases = []struct {
+		desc   string
+		params []errors.MD
+		expMsg string
+		expPrm string
+	}{
+		{
+			desc:   "public params",
+			params: []errors.MD{errors.PublicMD("max", 10), errors.PublicMD("min", 2)},
+			expMsg: "the length must be between 2 and 10",
+			expPrm: `{"max": 10, "min": 2}`,
+		},
+		{
+			desc:   "missing public params",
+			params: []errors.MD{errors.PublicMD("max", 10), {K: "min", V: 2}},
+			expMsg: "the length is out of range",
+			expPrm: `{"max": 10}`,
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			var v = Violation{
+				Field:  Field("name"),
+				Code:   lengthTestCode(true),
+				ID:     errors.ID("id1"),
+				Params: tc.params,
+			}
+
+			data, err := json.Marshal(v)
+			require.NoError(t, err)
+			assert.JSONEq(t, fmt.Sprintf(`{
+				"field": "name",
+				"code": "LengthTestCode",
+				"message": %q,
+				"id": "id1",
+				"params": %s
+			}`, tc.expMsg, tc.expPrm), string(data))
+		})
+	}
+}
+
+func TestViolation_MarshalJSON(t *testing.T) {
+	var v = Violation{
+		Field: Field("name"),
+		Code:  requiredTestCode(true),
+		ID:    errors.ID("id1"),
+		Params: []errors.MD{
+			errors.PublicMD("max", 10),
+			{K: "internal", V: "x"},
+		},
+	}
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"field": "name",
+		"code": "RequiredTestCode",
+		"message": "the field is required",
+		"id": "id1",
+		"params": {"max": 10}
+	}`, string(data))
+}
@@ -0,0 +1,31 @@
+/*
+Package validation collects field-level violations, of the input of an
+operation, as errors created by the go.fraixed.es/errors package and aggregates
+them in a single error.
+
+Each violation is an error with the Code which identifies it, its field path
+(see Path) and its parameters, for example the minimum length of a field, as
+metadata; the aggregate error has the ErrCodeInvalid Code and its own ID. Only
+the public parameters (see errors.PublicMD) are shown to the API clients.
+
+	var c validation.Collector
+	if req.Name == "" {
+		c.Add(validation.Field("name"), CodeRequired)
+	}
+
+	for i, it := range req.Items {
+		if it.Price < 0 {
+			c.Add(validation.Field("items").Index(i).Field("price"), CodeMin, errors.PublicMD("min", 0))
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+The violations can be obtained from the aggregate error with Violations, and
+errors.Is reports whether any of them has a specific Code, as it does with an
+aggregate created by errors.Join. ToJSON renders the aggregate error for the
+API clients.
+*/
+package validation
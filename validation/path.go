@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"strconv"
+)
+
+// Path is the path of a field, for example "items[3].price".
+type Path string
+
+// Field returns the path of the top-level field name.
+func Field(name string) Path {
+	return Path(name)
+}
+
+// Field returns the path of the field name of p.
+func (p Path) Field(name string) Path {
+	if p == "" {
+		return Path(name)
+	}
+
+	return p + "." + Path(name)
+}
+
+// Index returns the path of the element i of p.
+func (p Path) Index(i int) Path {
+	return p + "[" + Path(strconv.Itoa(i)) + "]"
+}
+
+// Key returns the path of the element with key k of p, for example a map.
+func (p Path) Key(k string) Path {
+	return p + "[" + Path(strconv.Quote(k)) + "]"
+}
+
+// String returns the string representation of p.
+func (p Path) String() string {
+	return string(p)
+}
@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	var tcases = []struct {
+		desc   string
+		path   Path
+		expStr string
+	}{
+		{
+			desc:   "field",
+			path:   Field("name"),
+			expStr: "name",
+		},
+		{
+			desc:   "nested fields",
+			path:   Field("user").Field("name"),
+			expStr: "user.name",
+		},
+		{
+			desc:   "index and field",
+			path:   Field("items").Index(3).Field("price"),
+			expStr: "items[3].price",
+		},
+		{
+			desc:   "key",
+			path:   Field("labels").Key("env"),
+			expStr: `labels["env"]`,
+		},
+		{
+			desc:   "field of empty path",
+			path:   Path("").Field("name"),
+			expStr: "name",
+		},
+	}
+
+	for i := range tcases {
+		var tc = tcases[i]
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expStr, tc.path.String())
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package validation
+
+// requiredTestCode is a silly example of a Code implementation with the only
+// purpose of testing this package.
+type requiredTestCode bool
+
+func (requiredTestCode) String() string {
+	return "RequiredTestCode"
+}
+
+func (requiredTestCode) Message() string {
+	return "the field is required"
+}
+
+// minTestCode is a silly example of a Code implementation with the only
+// purpose of testing this package.
+type minTestCode bool
+
+func (minTestCode) String() string {
+	return "MinTestCode"
+}
+
+func (minTestCode) Message() string {
+	return "the field is lower than the minimum"
+}
+
+// lengthTestCode is a silly example of a Code implementation, whose message is
+// a template, with the only purpose of testing this package.
+type lengthTestCode bool
+
+func (lengthTestCode) String() string {
+	return "LengthTestCode"
+}
+
+func (lengthTestCode) Message() string {
+	return "the length is out of range"
+}
+
+func (lengthTestCode) MessageTemplate() string {
+	return "the length must be between {min} and {max}"
+}